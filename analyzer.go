@@ -33,6 +33,8 @@ import (
 	"runtime/debug"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/tools/go/analysis"
@@ -179,6 +181,9 @@ type Metrics struct {
 	NumLines int `json:"lines"`
 	NumNosec int `json:"nosec"`
 	NumFound int `json:"found"`
+	// NumTruncated counts the analyses of a package by an SSA analyzer
+	// stopped short by an analysis budget.
+	NumTruncated int `json:"truncated"`
 }
 
 // Merge merges the metrics from another Metrics object into this one.
@@ -190,6 +195,7 @@ func (m *Metrics) Merge(other *Metrics) {
 	m.NumLines += other.NumLines
 	m.NumNosec += other.NumNosec
 	m.NumFound += other.NumFound
+	m.NumTruncated += other.NumTruncated
 }
 
 // Analyzer object is the main object of gosec. It has methods to load and analyze
@@ -219,6 +225,39 @@ type Analyzer struct {
 	trackSuppressions bool
 	concurrency       int
 	analyzerSet       *analyzers.AnalyzerSet
+	// objectFacts bridges analysis facts of an enclosing go/analysis driver
+	// (see the goanalysis package) to the analyzers. It is nil when gosec
+	// drives the analysis itself.
+	objectFacts *objectFacts
+}
+
+// objectFacts serializes access to the fact functions of an enclosing
+// analysis.Pass, which are not safe for concurrent use.
+type objectFacts struct {
+	mu         sync.Mutex
+	importFact func(types.Object, analysis.Fact) bool
+	exportFact func(types.Object, analysis.Fact)
+}
+
+func (f *objectFacts) importObjectFact(obj types.Object, fact analysis.Fact) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.importFact(obj, fact)
+}
+
+// exportObjectFact exports fact for obj. Facts implementing MergeFact are
+// merged with the fact already exported for obj while the lock is held, so
+// that concurrent analyzers contributing to the same fact do not lose updates.
+func (f *objectFacts) exportObjectFact(obj types.Object, fact analysis.Fact) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if merger, ok := fact.(interface{ MergeFact(analysis.Fact) }); ok {
+		existing := reflect.New(reflect.TypeOf(fact).Elem()).Interface().(analysis.Fact)
+		if f.importFact(obj, existing) {
+			merger.MergeFact(existing)
+		}
+	}
+	f.exportFact(obj, fact)
 }
 
 // NewAnalyzer builds a new analyzer.
@@ -257,6 +296,17 @@ func (gosec *Analyzer) SetConfig(conf Config) {
 	gosec.config = conf
 }
 
+// SetObjectFacts wires the object fact functions of an enclosing
+// go/analysis pass into the analyzer passes, so that analyzers exporting facts
+// (e.g. taint summaries) can follow flows across packages.
+func (gosec *Analyzer) SetObjectFacts(importFact func(types.Object, analysis.Fact) bool, exportFact func(types.Object, analysis.Fact)) {
+	if importFact == nil || exportFact == nil {
+		gosec.objectFacts = nil
+		return
+	}
+	gosec.objectFacts = &objectFacts{importFact: importFact, exportFact: exportFact}
+}
+
 // Config returns the current configuration
 func (gosec *Analyzer) Config() Config {
 	return gosec.config
@@ -601,13 +651,18 @@ func (gosec *Analyzer) CheckAnalyzersWithSSA(pkg *packages.Package, ssaResult *b
 }
 
 // checkAnalyzersWithSSA runs analyzers on a given package using an existing SSA result (Stateless API).
+// Analyses truncated by the analysis budget are logged and counted in the metrics.
 func (gosec *Analyzer) checkAnalyzersWithSSA(pkg *packages.Package, ssaResult *buildssa.SSA, allIgnores ignores) ([]*issue.Issue, *Metrics) {
 	sharedCache := ssautil.NewPackageAnalysisCache(ssaResult)
+	budget := gosec.analysisBudget()
+	audit, _ := gosec.config.IsGlobalEnabled(Audit)
 	ssaAnalyzerResult := &ssautil.SSAAnalyzerResult{
 		Config: gosec.Config(),
 		Logger: gosec.logger,
 		SSA:    ssaResult,
 		Shared: sharedCache,
+		Budget: budget,
+		Audit:  audit,
 	}
 
 	generatedFiles := gosec.generatedFiles(pkg)
@@ -620,6 +675,17 @@ func (gosec *Analyzer) checkAnalyzersWithSSA(pkg *packages.Package, ssaResult *b
 
 	for index, analyzer := range gosec.analyzerSet.Analyzers {
 		runner.Go(func() error {
+			// Analyzers not started within the budget of the package are skipped
+			if budget.Expired() {
+				budget.Truncate(analyzer.Name, ssautil.TruncatedTimeout)
+				return nil
+			}
+			var importFact func(types.Object, analysis.Fact) bool
+			var exportFact func(types.Object, analysis.Fact)
+			if gosec.objectFacts != nil && len(analyzer.FactTypes) > 0 {
+				importFact = gosec.objectFacts.importObjectFact
+				exportFact = gosec.objectFacts.exportObjectFact
+			}
 			pass := &analysis.Pass{
 				Analyzer:     analyzer,
 				Fset:         pkg.Fset,
@@ -633,8 +699,8 @@ func (gosec *Analyzer) checkAnalyzersWithSSA(pkg *packages.Package, ssaResult *b
 					buildssa.Analyzer: ssaAnalyzerResult,
 				},
 				Report:            func(d analysis.Diagnostic) {},
-				ImportObjectFact:  nil,
-				ExportObjectFact:  nil,
+				ImportObjectFact:  importFact,
+				ExportObjectFact:  exportFact,
 				ImportPackageFact: nil,
 				ExportPackageFact: nil,
 				AllObjectFacts:    nil,
@@ -675,9 +741,61 @@ func (gosec *Analyzer) checkAnalyzersWithSSA(pkg *packages.Package, ssaResult *b
 			issues = gosec.updateIssues(iss, issues, stats, allIgnores)
 		}
 	}
+
+	// Truncations are grouped by analyzer, so each analyzer is reported once
+	// per package with all the budgets it exhausted.
+	for _, truncation := range budget.Truncations() {
+		gosec.logger.Printf("Warning: %s analysis truncated in %s: %s budget exhausted, findings may be missing",
+			truncation.Analyzer, packageDir(pkg), strings.Join(truncation.Reasons, ", "))
+		stats.NumTruncated++
+	}
 	return issues, stats
 }
 
+// packageDir returns the directory of the files of pkg, or its name when it
+// has none. Packages loaded from a list of files share the import path
+// "command-line-arguments", which does not tell them apart.
+func packageDir(pkg *packages.Package) string {
+	if len(pkg.GoFiles) > 0 {
+		return filepath.Dir(pkg.GoFiles[0])
+	}
+	return pkg.Name
+}
+
+// analysisBudget returns the budget of the SSA analyzers for a package, as
+// set by the global options. Invalid values are logged and ignored.
+func (gosec *Analyzer) analysisBudget() *ssautil.Budget {
+	budget := &ssautil.Budget{
+		MaxDepth:            gosec.positiveGlobal(SSAMaxDepth),
+		TaintMaxDepth:       gosec.positiveGlobal(TaintMaxDepth),
+		TaintMaxCallerEdges: gosec.positiveGlobal(TaintMaxCallerEdges),
+	}
+	if value, err := gosec.config.GetGlobal(SSAAnalyzerTimeout); err == nil && value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			gosec.logger.Printf("Ignoring invalid %s value %q: expected a positive duration", SSAAnalyzerTimeout, value)
+		} else {
+			budget.Deadline = time.Now().Add(timeout)
+		}
+	}
+	return budget
+}
+
+// positiveGlobal returns the positive integer value of a global option, or 0
+// when it is not set or invalid.
+func (gosec *Analyzer) positiveGlobal(option GlobalOption) int {
+	value, err := gosec.config.GetGlobal(option)
+	if err != nil || value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		gosec.logger.Printf("Ignoring invalid %s value %q: expected a positive integer", option, value)
+		return 0
+	}
+	return n
+}
+
 func (gosec *Analyzer) generatedFiles(pkg *packages.Package) map[string]bool {
 	generatedFiles := map[string]bool{}
 	for _, file := range pkg.Syntax {
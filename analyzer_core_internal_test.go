@@ -5,12 +5,14 @@ import (
 	"go/types"
 	"io"
 	"log"
+	"strings"
 	"testing"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/packages"
 
+	"github.com/securego/gosec/v2/internal/ssautil"
 	"github.com/securego/gosec/v2/issue"
 )
 
@@ -78,6 +80,76 @@ func TestCheckAnalyzersWithSSAWrapperMergesIssues(t *testing.T) {
 	}
 }
 
+func TestCheckAnalyzersWithSSAReportsTruncatedAnalyses(t *testing.T) {
+	t.Parallel()
+
+	config := NewConfig()
+	config.SetGlobal(SSAMaxDepth, "7")
+	config.SetGlobal(TaintMaxDepth, "many")
+	var logs strings.Builder
+	a := NewAnalyzer(config, false, false, false, 1, log.New(&logs, "", 0))
+	a.analyzerSet.Register(&analysis.Analyzer{
+		Name: "T999",
+		Run: func(pass *analysis.Pass) (any, error) {
+			ssaResult, err := ssautil.GetSSAResult(pass)
+			if err != nil {
+				return nil, err
+			}
+			if ssaResult.Budget.MaxDepth != 7 || ssaResult.Budget.TaintMaxDepth != 0 {
+				t.Errorf("unexpected budget: %#v", ssaResult.Budget)
+			}
+			// Reported once, however many functions hit the budget
+			ssaResult.Budget.Truncate(pass.Analyzer.Name, ssautil.TruncatedMaxDepth)
+			ssaResult.Budget.Truncate(pass.Analyzer.Name, ssautil.TruncatedMaxDepth)
+			return nil, nil
+		},
+	}, false)
+
+	pkg := &packages.Package{Name: "pkg", PkgPath: "command-line-arguments", GoFiles: []string{"/src/app/pkg/main.go"}}
+	a.CheckAnalyzersWithSSA(pkg, &buildssa.SSA{})
+	_, stats, errs := a.Report()
+
+	if stats.NumTruncated != 1 {
+		t.Fatalf("unexpected truncated count: got %d want 1", stats.NumTruncated)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("truncated analyses must not be reported as errors: %#v", errs)
+	}
+	want := "T999 analysis truncated in /src/app/pkg: ssa-max-depth budget exhausted"
+	if got := logs.String(); strings.Count(got, want) != 1 {
+		t.Fatalf("unexpected log output: %q", got)
+	}
+}
+
+func TestCheckAnalyzersWithSSASkipsAnalyzersAfterTimeout(t *testing.T) {
+	t.Parallel()
+
+	config := NewConfig()
+	config.SetGlobal(SSAAnalyzerTimeout, "1ns")
+	var logs strings.Builder
+	a := NewAnalyzer(config, false, false, false, 1, log.New(&logs, "", 0))
+	ran := false
+	a.analyzerSet.Register(&analysis.Analyzer{
+		Name: "T999",
+		Run: func(*analysis.Pass) (any, error) {
+			ran = true
+			return nil, nil
+		},
+	}, false)
+
+	issues, stats := a.checkAnalyzersWithSSA(&packages.Package{Name: "pkg", PkgPath: "example.com/pkg"}, &buildssa.SSA{}, nil)
+
+	if ran || len(issues) != 0 {
+		t.Fatalf("expected the analyzer to be skipped")
+	}
+	if stats.NumTruncated != 1 {
+		t.Fatalf("expected a truncated analysis, got %d", stats.NumTruncated)
+	}
+	if !strings.Contains(logs.String(), "T999 analysis truncated in pkg: ssa-analyzer-timeout") {
+		t.Fatalf("unexpected log output: %q", logs.String())
+	}
+}
+
 func TestBuildSSANilPackage(t *testing.T) {
 	t.Parallel()
 
@@ -18,18 +18,22 @@ import (
 	"errors"
 	"fmt"
 	"go/build"
+	"go/types"
 	"log"
+	"reflect"
 	"regexp"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/packages"
 
 	"github.com/securego/gosec/v2"
 	"github.com/securego/gosec/v2/analyzers"
 	"github.com/securego/gosec/v2/issue"
 	"github.com/securego/gosec/v2/rules"
+	"github.com/securego/gosec/v2/taint"
 	"github.com/securego/gosec/v2/testutils"
 )
 
@@ -2571,6 +2575,62 @@ func main() {
 			analyzer.CheckAnalyzers(pkgs[0])
 		})
 
+		It("should keep the taint facts of analyzers running concurrently", func() {
+			// Two copies of the SQL injection analyzer do the same work, so
+			// that they export facts for the same object at the same time.
+			defs, _ := analyzers.Generate(false, analyzers.NewAnalyzerFilter(false, "G701")).AnalyzersInfo()
+			copyDef := defs["G701"]
+			copyDef.ID = "T701"
+			defs[copyDef.ID] = copyDef
+			concurrentAnalyzer := gosec.NewAnalyzer(nil, false, false, false, len(defs), logger)
+			concurrentAnalyzer.LoadAnalyzers(defs, nil)
+
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("run.go", `
+package main
+
+import "database/sql"
+
+func Run(db *sql.DB, q string) {
+	_, _ = db.Query(q)
+}
+
+func main() {}
+`)
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			pkgs := pkg.Pkgs()
+			Expect(pkgs).To(HaveLen(1))
+			run := pkgs[0].Types.Scope().Lookup("Run")
+
+			for range 10 {
+				// The store hands out shallow copies, as the go/analysis
+				// drivers do, so the maps of a stored fact are shared. It
+				// starts with the summary of another rule, which must be
+				// kept without updating its map in place.
+				stale := map[string]taint.FunctionSummary{"G000": {ReturnParams: []int{1}}}
+				facts := map[types.Object]analysis.Fact{run: &taint.FunctionFact{Summaries: stale}}
+				concurrentAnalyzer.SetObjectFacts(func(obj types.Object, fact analysis.Fact) bool {
+					stored, ok := facts[obj]
+					if ok {
+						reflect.ValueOf(fact).Elem().Set(reflect.ValueOf(stored).Elem())
+					}
+					return ok
+				}, func(obj types.Object, fact analysis.Fact) {
+					facts[obj] = fact
+				})
+				concurrentAnalyzer.CheckAnalyzers(pkgs[0])
+
+				fact, ok := facts[run].(*taint.FunctionFact)
+				Expect(ok).To(BeTrue())
+				Expect(fact.Summaries).To(HaveKey("G000"))
+				Expect(fact.Summaries).To(HaveKey("G701"))
+				Expect(fact.Summaries).To(HaveKey("T701"))
+				Expect(stale).To(HaveLen(1))
+			}
+		})
+
 		It("should handle CheckRules with no rules loaded", func() {
 			pkg := testutils.NewTestPackage()
 			defer pkg.Close()
@@ -3,6 +3,9 @@ package analyzers_test
 import (
 	"fmt"
 	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -79,6 +82,14 @@ var _ = Describe("gosec analyzers", func() {
 			runner("G122", testutils.SampleCodeG122)
 		})
 
+		It("should detect archive symlink and hardlink escapes", func() {
+			runner("G308", testutils.SampleCodeG308)
+		})
+
+		It("should detect file creation races", func() {
+			runner("G309", testutils.SampleCodeG309)
+		})
+
 		It("should detect TLS resumption VerifyPeerCertificate bypass patterns", func() {
 			runner("G123", testutils.SampleCodeG123)
 		})
@@ -87,6 +98,43 @@ var _ = Describe("gosec analyzers", func() {
 			runner("G124", testutils.SampleCodeG124)
 		})
 
+		It("should detect goroutines leaked by unbuffered channel sends", func() {
+			runner("G127", testutils.SampleCodeG127)
+		})
+
+		It("should detect ignored errors of security-critical calls", func() {
+			runner("G128", testutils.SampleCodeG128)
+		})
+
+		It("should audit the use of cgo and raw system calls", func() {
+			runner("G129", testutils.SampleCodeG129)
+		})
+
+		It("should report cgo issues in the original source file", func() {
+			sample := testutils.SampleCodeG129[0]
+			code := sample.Code[0]
+			lineOf := func(text string) string {
+				return strconv.Itoa(strings.Count(code[:strings.Index(code, text)], "\n") + 1)
+			}
+			analyzer.SetConfig(sample.Config)
+			analyzer.LoadAnalyzers(analyzers.Generate(false, analyzers.NewAnalyzerFilter(false, "G129")).AnalyzersInfo())
+			pkg := testutils.NewTestPackage()
+			defer pkg.Close()
+			pkg.AddFile("cgo.go", code)
+			err := pkg.Build()
+			Expect(err).ShouldNot(HaveOccurred())
+			err = analyzer.Process(buildTags, pkg.Path)
+			Expect(err).ShouldNot(HaveOccurred())
+			issues, _, _ := analyzer.Report()
+			Expect(issues).Should(HaveLen(2))
+			for _, iss := range issues {
+				Expect(filepath.Base(iss.File)).Should(Equal("cgo.go"))
+				Expect(iss.Code).Should(ContainSubstring(iss.Line + ":"))
+			}
+			lines := []string{issues[0].Line, issues[1].Line}
+			Expect(lines).Should(ConsistOf(lineOf(`import "C"`), lineOf("C.strlen")))
+		})
+
 		It("should detect hardcoded nonce/IV", func() {
 			runner("G407", testutils.SampleCodeG407)
 		})
@@ -99,6 +147,10 @@ var _ = Describe("gosec analyzers", func() {
 			runner("G602", testutils.SampleCodeG602)
 		})
 
+		It("should detect integer overflow in arithmetic used for allocations and indexing", func() {
+			runner("G603", testutils.SampleCodeG603)
+		})
+
 		It("should detect SQL injection via taint analysis", func() {
 			runner("G701", testutils.SampleCodeG701)
 		})
@@ -138,5 +190,17 @@ var _ = Describe("gosec analyzers", func() {
 		It("should detect open redirect via taint analysis", func() {
 			runner("G710", testutils.SampleCodeG710)
 		})
+
+		It("should detect HTTP response header injection via taint analysis", func() {
+			runner("G711", testutils.SampleCodeG711)
+		})
+
+		It("should detect allocations sized by untrusted input via taint analysis", func() {
+			runner("G712", testutils.SampleCodeG712)
+		})
+
+		It("should detect unsafe reflection and plugin loading via taint analysis", func() {
+			runner("G713", testutils.SampleCodeG713)
+		})
 	})
 })
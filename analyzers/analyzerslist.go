@@ -103,6 +103,27 @@ var (
 		CWE:         "CWE-601",
 	}
 
+	HeaderInjectionRule = taint.RuleInfo{
+		ID:          "G711",
+		Description: "HTTP response header injection via user input",
+		Severity:    "MEDIUM",
+		CWE:         "CWE-113",
+	}
+
+	UnboundedAllocationRule = taint.RuleInfo{
+		ID:          "G712",
+		Description: "Memory allocation sized by untrusted input",
+		Severity:    "MEDIUM",
+		CWE:         "CWE-789",
+	}
+
+	UnsafeReflectionRule = taint.RuleInfo{
+		ID:          "G713",
+		Description: "Untrusted input selects reflected methods, fields or plugins",
+		Severity:    "HIGH",
+		CWE:         "CWE-470",
+	}
+
 	FormParsingLimitRule = taint.RuleInfo{
 		ID:          "G120",
 		Description: "Unbounded multipart form parsing can cause memory exhaustion",
@@ -157,7 +178,13 @@ var defaultAnalyzers = []AnalyzerDefinition{
 	{"G122", "Filesystem TOCTOU race risk in filepath.Walk/WalkDir callbacks", newWalkSymlinkRaceAnalyzer},
 	{"G123", "TLS resumption may bypass VerifyPeerCertificate when VerifyConnection is unset", newTLSResumptionVerifyPeerAnalyzer},
 	{"G124", "Insecure HTTP cookie configuration missing Secure, HttpOnly, or SameSite attributes", newInsecureCookieAnalyzer},
+	{"G127", "Goroutine leak from unbuffered channel send without receiver", newGoroutineLeakAnalyzer},
+	{"G128", "Unchecked error of a security-critical call", newUncheckedSecurityErrorAnalyzer},
+	{"G129", "Audit the use of cgo and raw system calls", newCgoAuditAnalyzer},
+	{"G308", "Archive symlink/hardlink target escapes the extraction directory", newArchiveLinkEscapeAnalyzer},
+	{"G309", "File creation race after a stat check or in a shared temporary directory", newFileCreationRaceAnalyzer},
 	{"G602", "Possible slice bounds out of range", newSliceBoundsAnalyzer},
+	{"G603", "Integer overflow in arithmetic used for allocation sizes, slice bounds or unsafe offsets", newArithmeticOverflowAnalyzer},
 	{"G407", "Use of hardcoded IV/nonce for encryption", newHardCodedNonce},
 	{"G408", "Stateful misuse of ssh.PublicKeyCallback leading to auth bypass", newSSHCallbackAnalyzer},
 	{"G701", "SQL injection via taint analysis", newSQLInjectionAnalyzer},
@@ -170,6 +197,9 @@ var defaultAnalyzers = []AnalyzerDefinition{
 	{"G708", "Server-side template injection via taint analysis", newSSTIAnalyzer},
 	{"G709", "Unsafe deserialization of untrusted data via taint analysis", newUnsafeDeserializationAnalyzer},
 	{"G710", "Open redirect via taint analysis", newOpenRedirectAnalyzer},
+	{"G711", "HTTP response header injection via taint analysis", newHeaderInjectionAnalyzer},
+	{"G712", "Memory allocation sized by untrusted input via taint analysis", newUnboundedAllocationAnalyzer},
+	{"G713", "Unsafe reflection and plugin loading via taint analysis", newUnsafeReflectionAnalyzer},
 }
 
 // Generate the list of analyzers to use
@@ -208,6 +238,9 @@ func DefaultTaintAnalyzers() []*analysis.Analyzer {
 	deserConfig := UnsafeDeserialization()
 	formConfig := FormParsingLimits()
 	openRedirectConfig := OpenRedirect()
+	headerConfig := HeaderInjection()
+	allocationConfig := UnboundedAllocation()
+	reflectionConfig := UnsafeReflection()
 
 	return []*analysis.Analyzer{
 		taint.NewGosecAnalyzer(&SQLInjectionRule, &sqlConfig),
@@ -221,5 +254,8 @@ func DefaultTaintAnalyzers() []*analysis.Analyzer {
 		taint.NewGosecAnalyzer(&UnsafeDeserializationRule, &deserConfig),
 		taint.NewGosecAnalyzer(&FormParsingLimitRule, &formConfig),
 		taint.NewGosecAnalyzer(&OpenRedirectRule, &openRedirectConfig),
+		taint.NewGosecAnalyzer(&HeaderInjectionRule, &headerConfig),
+		taint.NewGosecAnalyzer(&UnboundedAllocationRule, &allocationConfig),
+		taint.NewGosecAnalyzer(&UnsafeReflectionRule, &reflectionConfig),
 	}
 }
@@ -86,6 +86,24 @@ func TestTaintAnalyzerConstructors(t *testing.T) {
 			id:          "G710",
 			description: "Open redirect via taint analysis",
 		},
+		{
+			name:        "HeaderInjection",
+			constructor: newHeaderInjectionAnalyzer,
+			id:          "G711",
+			description: "HTTP response header injection via taint analysis",
+		},
+		{
+			name:        "UnboundedAllocation",
+			constructor: newUnboundedAllocationAnalyzer,
+			id:          "G712",
+			description: "Memory allocation sized by untrusted input via taint analysis",
+		},
+		{
+			name:        "UnsafeReflection",
+			constructor: newUnsafeReflectionAnalyzer,
+			id:          "G713",
+			description: "Unsafe reflection and plugin loading via taint analysis",
+		},
 		{
 			name:        "FormParsingLimit",
 			constructor: newFormParsingLimitAnalyzer,
@@ -125,7 +143,7 @@ func TestTaintAnalyzerConstructors(t *testing.T) {
 
 // TestDefaultAnalyzersIncludeTaint tests that default analyzers include taint rules.
 func TestDefaultAnalyzersIncludeTaint(t *testing.T) {
-	expectedTaintIDs := []string{"G701", "G702", "G703", "G704", "G705", "G706", "G707", "G708", "G709", "G710"}
+	expectedTaintIDs := []string{"G701", "G702", "G703", "G704", "G705", "G706", "G707", "G708", "G709", "G710", "G711", "G712", "G713"}
 
 	found := make(map[string]bool)
 	for _, def := range defaultAnalyzers {
@@ -143,7 +161,7 @@ func TestDefaultAnalyzersIncludeTaint(t *testing.T) {
 func TestGenerateIncludesTaintAnalyzers(t *testing.T) {
 	analyzerList := Generate(false)
 
-	expectedTaintIDs := []string{"G701", "G702", "G703", "G704", "G705", "G706", "G707", "G708", "G709", "G710"}
+	expectedTaintIDs := []string{"G701", "G702", "G703", "G704", "G705", "G706", "G707", "G708", "G709", "G710", "G711", "G712", "G713"}
 
 	for _, id := range expectedTaintIDs {
 		if _, ok := analyzerList.Analyzers[id]; !ok {
@@ -308,7 +326,7 @@ func TestAnalyzerList_AnalyzersInfo(t *testing.T) {
 func TestDefaultTaintAnalyzers(t *testing.T) {
 	analyzers := DefaultTaintAnalyzers()
 
-	expectedCount := 11 // SQL, Command, Path, SSRF, XSS, Log, SMTP, SSTI, Deserialization, FormParsing, OpenRedirect
+	expectedCount := 14 // SQL, Command, Path, SSRF, XSS, Log, SMTP, SSTI, Deserialization, FormParsing, OpenRedirect, HeaderInjection, UnboundedAllocation, UnsafeReflection
 	if len(analyzers) != expectedCount {
 		t.Errorf("Expected %d taint analyzers, got %d", expectedCount, len(analyzers))
 	}
@@ -324,6 +342,9 @@ func TestDefaultTaintAnalyzers(t *testing.T) {
 		"G708": false,
 		"G709": false,
 		"G710": false,
+		"G711": false,
+		"G712": false,
+		"G713": false,
 		"G120": false,
 	}
 
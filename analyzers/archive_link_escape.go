@@ -0,0 +1,444 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/securego/gosec/v2/internal/ssautil"
+	"github.com/securego/gosec/v2/issue"
+)
+
+const msgArchiveLinkEscape = "Archive entry link target (tar.Header.Linkname) is used in os.Symlink/os.Link without validation; validate it with filepath.IsLocal or extract through os.Root"
+
+func newArchiveLinkEscapeAnalyzer(id string, description string) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:     id,
+		Doc:      description,
+		Run:      runArchiveLinkEscapeAnalysis,
+		Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	}
+}
+
+func runArchiveLinkEscapeAnalysis(pass *analysis.Pass) (any, error) {
+	ssaResult, err := ssautil.GetSSAResult(pass)
+	if err != nil {
+		return nil, err
+	}
+
+	state := newArchiveLinkEscapeState(pass)
+	defer state.Release()
+
+	for _, fn := range collectAnalyzerFunctions(ssaResult.SSA.SrcFuncs) {
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				callInstr, ok := instr.(ssa.CallInstruction)
+				if !ok {
+					continue
+				}
+				common := callInstr.Common()
+				if common == nil || !isLinkCreationCall(common) || len(common.Args) == 0 {
+					continue
+				}
+				if !state.dependsOnLinkname(common.Args[0], 0, make(map[ssa.Value]struct{})) {
+					continue
+				}
+				if state.isLinknameValidated(block, common) {
+					continue
+				}
+				state.addIssue(instr.Pos())
+			}
+		}
+	}
+
+	if len(state.issuesByPos) == 0 {
+		return nil, nil
+	}
+
+	issues := make([]*issue.Issue, 0, len(state.issuesByPos))
+	for _, i := range state.issuesByPos {
+		issues = append(issues, i)
+	}
+
+	return issues, nil
+}
+
+type archiveLinkEscapeState struct {
+	*BaseAnalyzerState
+	issuesByPos map[token.Pos]*issue.Issue
+}
+
+func newArchiveLinkEscapeState(pass *analysis.Pass) *archiveLinkEscapeState {
+	return &archiveLinkEscapeState{
+		BaseAnalyzerState: NewBaseState(pass),
+		issuesByPos:       make(map[token.Pos]*issue.Issue),
+	}
+}
+
+func (s *archiveLinkEscapeState) addIssue(pos token.Pos) {
+	if pos == token.NoPos {
+		return
+	}
+	if _, exists := s.issuesByPos[pos]; exists {
+		return
+	}
+	s.issuesByPos[pos] = newIssue(s.Pass.Analyzer.Name, msgArchiveLinkEscape, s.Pass.Fset, pos, issue.High, issue.Medium)
+}
+
+// isLinkCreationCall reports whether the call creates a symbolic or hard link
+// outside of an os.Root. Root-scoped link creation cannot escape the root.
+func isLinkCreationCall(common *ssa.CallCommon) bool {
+	callee := common.StaticCallee()
+	if callee == nil || callee.Pkg == nil || callee.Pkg.Pkg == nil {
+		return false
+	}
+	if isRootScopedFilesystemCall(callee) {
+		return false
+	}
+	if callee.Pkg.Pkg.Path() != "os" {
+		return false
+	}
+	switch callee.Name() {
+	case "Symlink", "Link":
+		return true
+	}
+	return false
+}
+
+// dependsOnLinkname reports whether v is derived from the Linkname field of an
+// archive/tar.Header.
+func (s *archiveLinkEscapeState) dependsOnLinkname(v ssa.Value, depth int, visited map[ssa.Value]struct{}) bool {
+	if v == nil || s.DepthExceeded(depth) {
+		return false
+	}
+	if _, seen := visited[v]; seen {
+		return false
+	}
+	visited[v] = struct{}{}
+
+	switch val := v.(type) {
+	case *ssa.FieldAddr:
+		return isTarLinknameField(val.X.Type(), val.Field)
+	case *ssa.Field:
+		return isTarLinknameField(val.X.Type(), val.Field)
+	case *ssa.UnOp:
+		if s.dependsOnLinkname(val.X, depth+1, visited) {
+			return true
+		}
+		if val.Op == token.MUL {
+			for _, stored := range storedValues(val.X) {
+				if s.dependsOnLinkname(stored, depth+1, visited) {
+					return true
+				}
+			}
+		}
+	case *ssa.BinOp:
+		return s.dependsOnLinkname(val.X, depth+1, visited) || s.dependsOnLinkname(val.Y, depth+1, visited)
+	case *ssa.Convert:
+		return s.dependsOnLinkname(val.X, depth+1, visited)
+	case *ssa.ChangeType:
+		return s.dependsOnLinkname(val.X, depth+1, visited)
+	case *ssa.Phi:
+		for _, edge := range val.Edges {
+			if s.dependsOnLinkname(edge, depth+1, visited) {
+				return true
+			}
+		}
+	case *ssa.Extract:
+		return s.dependsOnLinkname(val.Tuple, depth+1, visited)
+	case *ssa.Slice:
+		return s.dependsOnLinkname(val.X, depth+1, visited)
+	case *ssa.Alloc:
+		// Variadic arguments (e.g. filepath.Join) are stored element-wise
+		// into a backing array before being sliced.
+		for _, ref := range safeReferrers(val) {
+			indexAddr, ok := ref.(*ssa.IndexAddr)
+			if !ok {
+				continue
+			}
+			for _, stored := range storedValues(indexAddr) {
+				if s.dependsOnLinkname(stored, depth+1, visited) {
+					return true
+				}
+			}
+		}
+	case *ssa.Call:
+		for _, arg := range val.Call.Args {
+			if s.dependsOnLinkname(arg, depth+1, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isTarLinknameField(t types.Type, field int) bool {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj() == nil || named.Obj().Pkg() == nil {
+		return false
+	}
+	if named.Obj().Pkg().Path() != "archive/tar" || named.Obj().Name() != "Header" {
+		return false
+	}
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok || field >= st.NumFields() {
+		return false
+	}
+	return st.Field(field).Name() == "Linkname"
+}
+
+// linkGuard is a check validating the target of a link.
+type linkGuard int
+
+const (
+	noLinkGuard        linkGuard = iota
+	localLinkGuard               // filepath.IsLocal(hdr.Linkname)
+	containedLinkGuard           // strings.HasPrefix(resolved target, cleaned destination)
+	relativeLinkGuard            // !filepath.IsAbs(hdr.Linkname)
+)
+
+// isLinknameValidated reports whether block, containing the link creation
+// call link, is only reachable through the passing branches of checks
+// validating the link name. A symbolic link target resolved within the
+// destination must also be relative, since an absolute target is not joined.
+func (s *archiveLinkEscapeState) isLinknameValidated(block *ssa.BasicBlock, link *ssa.CallCommon) bool {
+	var contained, relative bool
+	doms := GetDominators(block)
+	for i, dom := range doms {
+		if i+1 >= len(doms) || len(dom.Instrs) == 0 {
+			continue
+		}
+		ifInstr, ok := dom.Instrs[len(dom.Instrs)-1].(*ssa.If)
+		if !ok || len(dom.Succs) != 2 {
+			continue
+		}
+
+		cond := ifInstr.Cond
+		negated := false
+		if not, ok := cond.(*ssa.UnOp); ok && not.Op == token.NOT {
+			cond = not.X
+			negated = true
+		}
+		guard := s.linknameGuard(cond, link)
+		if guard == noLinkGuard {
+			continue
+		}
+
+		// filepath.IsAbs passes when it returns false
+		passing := dom.Succs[0]
+		if negated != (guard == relativeLinkGuard) {
+			passing = dom.Succs[1]
+		}
+		if !passing.Dominates(block) {
+			continue
+		}
+		switch guard {
+		case localLinkGuard:
+			return true
+		case containedLinkGuard:
+			contained = true
+		case relativeLinkGuard:
+			relative = true
+		}
+	}
+	return contained && (relative || !isSymlinkCall(link))
+}
+
+// linknameGuard returns the validation of the link name performed by cond for
+// the link creation call link: filepath.IsLocal on the link name,
+// filepath.IsAbs on the target of a symbolic link, or strings.HasPrefix of the
+// resolved and cleaned link target against the cleaned destination directory.
+// A symbolic link target resolves against the directory of the link, a hard
+// link target against the working directory like any other path.
+func (s *archiveLinkEscapeState) linknameGuard(cond ssa.Value, link *ssa.CallCommon) linkGuard {
+	call, ok := cond.(*ssa.Call)
+	if !ok {
+		return noLinkGuard
+	}
+	callee := call.Call.StaticCallee()
+	if callee == nil || callee.Pkg == nil || callee.Pkg.Pkg == nil {
+		return noLinkGuard
+	}
+
+	args := call.Call.Args
+	switch callee.Pkg.Pkg.Path() + "." + callee.Name() {
+	case "path/filepath.IsLocal":
+		if len(args) > 0 && s.dependsOnLinkname(args[0], 0, make(map[ssa.Value]struct{})) {
+			return localLinkGuard
+		}
+	case "path/filepath.IsAbs":
+		if len(args) > 0 && isSymlinkCall(link) && (args[0] == link.Args[0] || isLinknameLoad(args[0])) {
+			return relativeLinkGuard
+		}
+	case "strings.HasPrefix":
+		if len(args) != 2 || !isCleanedPath(args[0]) || !s.dependsOnLinkname(args[0], 0, make(map[ssa.Value]struct{})) {
+			return noLinkGuard
+		}
+		if isSymlinkCall(link) && (len(link.Args) < 2 || !isJoinedToDirOf(args[0], link.Args[1])) {
+			return noLinkGuard
+		}
+		prefix := args[1]
+		// filepath.Clean(dest) + string(os.PathSeparator)
+		if binOp, ok := prefix.(*ssa.BinOp); ok && binOp.Op == token.ADD {
+			prefix = binOp.X
+		}
+		if isCleanedPath(prefix) && !isConstPath(prefix) && !s.dependsOnLinkname(prefix, 0, make(map[ssa.Value]struct{})) {
+			return containedLinkGuard
+		}
+	}
+	return noLinkGuard
+}
+
+// isLinknameLoad reports whether v reads the Linkname field of an
+// archive/tar.Header.
+func isLinknameLoad(v ssa.Value) bool {
+	switch val := v.(type) {
+	case *ssa.UnOp:
+		fieldAddr, ok := val.X.(*ssa.FieldAddr)
+		return ok && val.Op == token.MUL && isTarLinknameField(fieldAddr.X.Type(), fieldAddr.Field)
+	case *ssa.Field:
+		return isTarLinknameField(val.X.Type(), val.Field)
+	}
+	return false
+}
+
+// isSymlinkCall reports whether the link creation call creates a symbolic link.
+func isSymlinkCall(link *ssa.CallCommon) bool {
+	callee := link.StaticCallee()
+	return callee != nil && callee.Name() == "Symlink"
+}
+
+// isJoinedToDirOf reports whether the cleaned path v is built by
+// filepath.Join with filepath.Dir(linkPath) as its first element.
+func isJoinedToDirOf(v ssa.Value, linkPath ssa.Value) bool {
+	for {
+		if extract, ok := v.(*ssa.Extract); ok {
+			v = extract.Tuple
+		}
+		call, ok := v.(*ssa.Call)
+		if !ok {
+			return false
+		}
+		callee := call.Call.StaticCallee()
+		if callee == nil || callee.Pkg == nil || callee.Pkg.Pkg.Path() != "path/filepath" || len(call.Call.Args) == 0 {
+			return false
+		}
+		switch callee.Name() {
+		case "Clean", "Abs":
+			v = call.Call.Args[0]
+			continue
+		case "Join":
+		default:
+			return false
+		}
+
+		// First element of the variadic slice of filepath.Join
+		slice, ok := call.Call.Args[0].(*ssa.Slice)
+		if !ok {
+			return false
+		}
+		array, ok := slice.X.(*ssa.Alloc)
+		if !ok {
+			return false
+		}
+		for _, ref := range safeReferrers(array) {
+			indexAddr, ok := ref.(*ssa.IndexAddr)
+			if !ok {
+				continue
+			}
+			if idx, ok := GetConstantInt64(indexAddr.Index); !ok || idx != 0 {
+				continue
+			}
+			for _, first := range storedValues(indexAddr) {
+				dir, ok := first.(*ssa.Call)
+				if !ok {
+					return false
+				}
+				dirCallee := dir.Call.StaticCallee()
+				if dirCallee == nil || dirCallee.Pkg == nil || dirCallee.Pkg.Pkg.Path() != "path/filepath" ||
+					dirCallee.Name() != "Dir" || dir.Call.Args[0] != linkPath {
+					return false
+				}
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// isCleanedPath reports whether v is the result of filepath.Clean,
+// filepath.Join or filepath.Abs, which return cleaned paths.
+func isCleanedPath(v ssa.Value) bool {
+	if extract, ok := v.(*ssa.Extract); ok {
+		v = extract.Tuple
+	}
+	call, ok := v.(*ssa.Call)
+	if !ok {
+		return false
+	}
+	callee := call.Call.StaticCallee()
+	if callee == nil || callee.Pkg == nil || callee.Pkg.Pkg.Path() != "path/filepath" {
+		return false
+	}
+	switch callee.Name() {
+	case "Clean", "Join", "Abs":
+		return true
+	}
+	return false
+}
+
+// isConstPath reports whether the cleaned path v is built from constants only,
+// e.g. filepath.Clean("/"), which is not a destination directory.
+func isConstPath(v ssa.Value) bool {
+	if extract, ok := v.(*ssa.Extract); ok {
+		v = extract.Tuple
+	}
+	call, ok := v.(*ssa.Call)
+	if !ok {
+		return false
+	}
+	for _, arg := range call.Call.Args {
+		if _, ok := arg.(*ssa.Const); ok {
+			continue
+		}
+		// Elements of the variadic slice of filepath.Join
+		slice, ok := arg.(*ssa.Slice)
+		if !ok {
+			return false
+		}
+		array, ok := slice.X.(*ssa.Alloc)
+		if !ok {
+			return false
+		}
+		for _, ref := range safeReferrers(array) {
+			if indexAddr, ok := ref.(*ssa.IndexAddr); ok {
+				for _, stored := range storedValues(indexAddr) {
+					if _, ok := stored.(*ssa.Const); !ok {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
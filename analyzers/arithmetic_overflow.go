@@ -0,0 +1,373 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"math/big"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/securego/gosec/v2/internal/ssautil"
+	"github.com/securego/gosec/v2/issue"
+)
+
+// Uses of an arithmetic result checked for overflow.
+const (
+	useAllocationSize = "allocation size"
+	useSliceBound     = "slice bound"
+	useIndex          = "index"
+	useUnsafeOffset   = "unsafe pointer offset"
+)
+
+func newArithmeticOverflowAnalyzer(id string, description string) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:     id,
+		Doc:      description,
+		Run:      runArithmeticOverflow,
+		Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	}
+}
+
+type arithmeticOverflowState struct {
+	*BaseAnalyzerState
+	issues map[token.Pos]*issue.Issue
+}
+
+// runArithmeticOverflow reports additions, multiplications and shifts that
+// can overflow their type before being used as an allocation size, a slice
+// bound, an index or an unsafe pointer offset.
+func runArithmeticOverflow(pass *analysis.Pass) (any, error) {
+	ssaResult, err := ssautil.GetSSAResult(pass)
+	if err != nil {
+		return nil, fmt.Errorf("building ssa representation: %w", err)
+	}
+
+	state := &arithmeticOverflowState{
+		BaseAnalyzerState: NewBaseState(pass),
+		issues:            make(map[token.Pos]*issue.Issue),
+	}
+	defer state.Release()
+
+	for _, fn := range collectAnalyzerFunctions(ssaResult.SSA.SrcFuncs) {
+		state.Reset()
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				state.checkInstruction(instr)
+			}
+		}
+	}
+
+	if len(state.issues) == 0 {
+		return nil, nil
+	}
+	issues := make([]*issue.Issue, 0, len(state.issues))
+	for _, i := range state.issues {
+		issues = append(issues, i)
+	}
+	return issues, nil
+}
+
+func (s *arithmeticOverflowState) checkInstruction(instr ssa.Instruction) {
+	switch v := instr.(type) {
+	case *ssa.MakeSlice:
+		s.checkUse(v.Len, useAllocationSize)
+		s.checkUse(v.Cap, useAllocationSize)
+	case *ssa.MakeChan:
+		s.checkUse(v.Size, useAllocationSize)
+	case *ssa.MakeMap:
+		s.checkUse(v.Reserve, useAllocationSize)
+	case *ssa.Slice:
+		s.checkUse(v.Low, useSliceBound)
+		s.checkUse(v.High, useSliceBound)
+		s.checkUse(v.Max, useSliceBound)
+	case *ssa.IndexAddr:
+		s.checkUse(v.Index, useIndex)
+	case *ssa.Index:
+		s.checkUse(v.Index, useIndex)
+	case *ssa.Call:
+		// unsafe.Add(ptr, len), unsafe.Slice(ptr, len), unsafe.String(ptr, len)
+		builtin, ok := v.Call.Value.(*ssa.Builtin)
+		if !ok || len(v.Call.Args) != 2 {
+			return
+		}
+		switch builtin.Name() {
+		case "Add", "Slice", "String":
+			s.checkUse(v.Call.Args[1], useUnsafeOffset)
+		}
+	case *ssa.Convert:
+		// unsafe.Pointer(uintptr(ptr) + off)
+		if !isUnsafePointer(v.Type()) {
+			return
+		}
+		add, ok := v.X.(*ssa.BinOp)
+		if !ok || add.Op != token.ADD {
+			return
+		}
+		for _, operand := range []ssa.Value{add.X, add.Y} {
+			if conv, ok := operand.(*ssa.Convert); ok && isUnsafePointer(conv.X.Type()) {
+				continue
+			}
+			s.checkUse(operand, useUnsafeOffset)
+		}
+	}
+}
+
+// checkUse reports v when it is computed by an arithmetic operation that can
+// overflow, looking through the conversions applied to the result.
+func (s *arithmeticOverflowState) checkUse(v ssa.Value, use string) {
+	for v != nil {
+		switch conv := v.(type) {
+		case *ssa.Convert:
+			v = conv.X
+			continue
+		case *ssa.ChangeType:
+			v = conv.X
+			continue
+		}
+		break
+	}
+	binOp, ok := v.(*ssa.BinOp)
+	if !ok || (binOp.Op != token.ADD && binOp.Op != token.MUL && binOp.Op != token.SHL) {
+		return
+	}
+	if _, found := s.issues[binOp.Pos()]; found || binOp.Pos() == token.NoPos {
+		return
+	}
+	info, err := GetIntTypeInfo(binOp.Type())
+	if err != nil {
+		return
+	}
+	// Word-sized arithmetic only overflows with extreme values, which are
+	// worth reporting when they come from untrusted input.
+	untrusted := s.isUntrustedInt(binOp.X, 0) || s.isUntrustedInt(binOp.Y, 0)
+	if info.Size == 64 && !untrusted {
+		return
+	}
+	if !s.mayOverflow(binOp, info) {
+		return
+	}
+	confidence := issue.Low
+	if untrusted {
+		confidence = issue.Medium
+	}
+	msg := fmt.Sprintf("Integer overflow in %s arithmetic used as %s", binOp.Type().Underlying().String(), use)
+	s.issues[binOp.Pos()] = newIssue(s.Pass.Analyzer.Name, msg, s.Pass.Fset, binOp.Pos(), issue.Medium, confidence)
+}
+
+// mayOverflow reports whether the range of the result of binOp, computed from
+// the ranges of its operands, exceeds the bounds of its type.
+func (s *arithmeticOverflowState) mayOverflow(binOp *ssa.BinOp, info IntTypeInfo) bool {
+	low, high, ok := s.arithmeticRange(binOp, info, 0)
+	if !ok {
+		return false
+	}
+	return low.Cmp(big.NewInt(info.Min)) < 0 || high.Cmp(new(big.Int).SetUint64(info.Max)) > 0
+}
+
+// arithmeticRange returns the bounds of the mathematical result of binOp,
+// before any wrap around, from the ranges of its operands.
+func (s *arithmeticOverflowState) arithmeticRange(binOp *ssa.BinOp, info IntTypeInfo, depth int) (*big.Int, *big.Int, bool) {
+	xLow, xHigh := s.operandRange(binOp.X, binOp.Block(), info, depth+1)
+
+	var corners []*big.Int
+	switch binOp.Op {
+	case token.ADD:
+		yLow, yHigh := s.operandRange(binOp.Y, binOp.Block(), info, depth+1)
+		corners = []*big.Int{new(big.Int).Add(xLow, yLow), new(big.Int).Add(xHigh, yHigh)}
+	case token.MUL:
+		yLow, yHigh := s.operandRange(binOp.Y, binOp.Block(), info, depth+1)
+		for _, x := range []*big.Int{xLow, xHigh} {
+			for _, y := range []*big.Int{yLow, yHigh} {
+				corners = append(corners, new(big.Int).Mul(x, y))
+			}
+		}
+	case token.SHL:
+		shiftInfo, err := GetIntTypeInfo(binOp.Y.Type())
+		if err != nil {
+			return nil, nil, false
+		}
+		yLow, yHigh := s.operandRange(binOp.Y, binOp.Block(), shiftInfo, depth+1)
+		if yLow.Sign() < 0 {
+			yLow = big.NewInt(0)
+		}
+		// Shifting by the width of the type or more clears every bit
+		if !yHigh.IsInt64() || yHigh.Int64() >= int64(info.Size) {
+			yHigh = big.NewInt(int64(info.Size))
+		}
+		for _, x := range []*big.Int{xLow, xHigh} {
+			for _, y := range []*big.Int{yLow, yHigh} {
+				corners = append(corners, new(big.Int).Lsh(x, uint(y.Int64())))
+			}
+		}
+	default:
+		return nil, nil, false
+	}
+
+	low, high := corners[0], corners[0]
+	for _, corner := range corners[1:] {
+		if corner.Cmp(low) < 0 {
+			low = corner
+		}
+		if corner.Cmp(high) > 0 {
+			high = corner
+		}
+	}
+	return low, high, true
+}
+
+// operandRange returns the bounds of v at block, narrowed from the bounds of
+// its type by constants, range checks, conversions from smaller types and
+// arithmetic that cannot overflow.
+func (s *arithmeticOverflowState) operandRange(v ssa.Value, block *ssa.BasicBlock, info IntTypeInfo, depth int) (*big.Int, *big.Int) {
+	if c, ok := v.(*ssa.Const); ok && c.Value != nil {
+		if val, ok := GetConstantInt64(c); ok {
+			return big.NewInt(val), big.NewInt(val)
+		}
+		if val, ok := GetConstantUint64(c); ok {
+			return new(big.Int).SetUint64(val), new(big.Int).SetUint64(val)
+		}
+	}
+
+	low := big.NewInt(info.Min)
+	high := new(big.Int).SetUint64(info.Max)
+	narrow := func(l, h *big.Int) {
+		if l.Cmp(low) > 0 && l.Cmp(high) <= 0 {
+			low = l
+		}
+		if h.Cmp(high) < 0 && h.Cmp(low) >= 0 {
+			high = h
+		}
+	}
+
+	if !s.DepthExceeded(depth) {
+		switch val := v.(type) {
+		case *ssa.Convert:
+			if srcInfo, err := GetIntTypeInfo(val.X.Type()); err == nil {
+				l, h := s.operandRange(val.X, block, srcInfo, depth+1)
+				if l.Cmp(big.NewInt(info.Min)) >= 0 && h.Cmp(new(big.Int).SetUint64(info.Max)) <= 0 {
+					narrow(l, h)
+				}
+			}
+		case *ssa.BinOp:
+			if l, h, ok := s.arithmeticRange(val, info, depth+1); ok {
+				if l.Cmp(big.NewInt(info.Min)) >= 0 && h.Cmp(new(big.Int).SetUint64(info.Max)) <= 0 {
+					narrow(l, h)
+				}
+			}
+		}
+	}
+
+	clear(s.Visited)
+	res := s.Analyzer.ResolveRange(v, block)
+	defer s.Analyzer.releaseResult(res)
+
+	bound := func(val uint64) *big.Int {
+		if info.Signed {
+			return big.NewInt(toInt64(val))
+		}
+		return new(big.Int).SetUint64(val)
+	}
+	if res.minValueSet {
+		narrow(bound(res.minValue), high)
+	}
+	if res.maxValueSet {
+		narrow(low, bound(res.maxValue))
+	}
+	return low, high
+}
+
+// isUntrustedInt reports whether v is an integer decoded from external input:
+// parsed by strconv or read with encoding/binary.
+func (s *arithmeticOverflowState) isUntrustedInt(v ssa.Value, depth int) bool {
+	if v == nil || s.DepthExceeded(depth) {
+		return false
+	}
+	switch val := v.(type) {
+	case *ssa.Convert:
+		return s.isUntrustedInt(val.X, depth+1)
+	case *ssa.ChangeType:
+		return s.isUntrustedInt(val.X, depth+1)
+	case *ssa.Extract:
+		return s.isUntrustedInt(val.Tuple, depth+1)
+	case *ssa.BinOp:
+		return s.isUntrustedInt(val.X, depth+1) || s.isUntrustedInt(val.Y, depth+1)
+	case *ssa.Phi:
+		if s.Visited[val] {
+			return false
+		}
+		s.Visited[val] = true
+		for _, edge := range val.Edges {
+			if s.isUntrustedInt(edge, depth+1) {
+				return true
+			}
+		}
+	case *ssa.Call:
+		return isIntDecodingCall(val.Common())
+	case *ssa.UnOp:
+		if val.Op != token.MUL {
+			return false
+		}
+		// var n uint32; binary.Read(r, order, &n)
+		alloc, ok := val.X.(*ssa.Alloc)
+		if !ok {
+			return false
+		}
+		for _, ref := range safeReferrers(alloc) {
+			iface, ok := ref.(*ssa.MakeInterface)
+			if !ok {
+				continue
+			}
+			for _, ifaceRef := range safeReferrers(iface) {
+				if call, ok := ifaceRef.(*ssa.Call); ok && isIntDecodingCall(call.Common()) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isIntDecodingCall reports whether call parses or decodes an integer.
+func isIntDecodingCall(call *ssa.CallCommon) bool {
+	var fn *types.Func
+	if call.IsInvoke() {
+		fn = call.Method
+	} else if callee := call.StaticCallee(); callee != nil {
+		fn, _ = callee.Object().(*types.Func)
+	}
+	if fn == nil || fn.Pkg() == nil {
+		return false
+	}
+	switch fn.Pkg().Path() {
+	case "strconv":
+		switch fn.Name() {
+		case "Atoi", "ParseInt", "ParseUint":
+			return true
+		}
+	case "encoding/binary":
+		return true
+	}
+	return false
+}
+
+func isUnsafePointer(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.UnsafePointer
+}
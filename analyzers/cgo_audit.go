@@ -0,0 +1,464 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/securego/gosec/v2/internal/ssautil"
+	"github.com/securego/gosec/v2/issue"
+)
+
+// cgo rewrites C.name into calls of the package function _Cfunc_name, and
+// import "C" into import _ "unsafe", keeping the preamble as its comment. The
+// rewritten files map back to the original ones through line directives.
+const (
+	cgoFuncPrefix      = "_Cfunc_"
+	cgoGeneratedHeader = "Code generated by cmd/cgo; DO NOT EDIT."
+)
+
+// cgoAllocators return C memory that must be released with C.free.
+var cgoAllocators = map[string]bool{"CString": true, "CBytes": true}
+
+// cgoHelpers are the conversions provided by cgo, which are not C functions.
+var cgoHelpers = map[string]bool{"CString": true, "CBytes": true, "GoString": true, "GoStringN": true, "GoBytes": true, "free": true}
+
+// rawSyscallPackages are the packages whose Syscall* and RawSyscall*
+// functions enter the kernel without the checks of the typed wrappers.
+var rawSyscallPackages = map[string]bool{"syscall": true, "golang.org/x/sys/unix": true, "golang.org/x/sys/windows": true}
+
+func newCgoAuditAnalyzer(id string, description string) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:     id,
+		Doc:      description,
+		Run:      runCgoAudit,
+		Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	}
+}
+
+type cgoAuditState struct {
+	*BaseAnalyzerState
+	issues map[token.Pos]*issue.Issue
+}
+
+func runCgoAudit(pass *analysis.Pass) (any, error) {
+	ssaResult, err := ssautil.GetSSAResult(pass)
+	if err != nil {
+		return nil, err
+	}
+	if !ssaResult.Audit {
+		return nil, nil
+	}
+
+	state := &cgoAuditState{
+		BaseAnalyzerState: NewBaseState(pass),
+		issues:            make(map[token.Pos]*issue.Issue),
+	}
+	defer state.Release()
+
+	for _, file := range pass.Files {
+		if pos, ok := cgoImport(file); ok {
+			state.addIssue(pos, "Use of cgo should be audited", issue.Low, issue.High)
+		}
+	}
+
+	for _, fn := range collectAnalyzerFunctions(ssaResult.SSA.SrcFuncs) {
+		// Skip the wrappers generated by cgo
+		if strings.HasPrefix(fn.Name(), "_C") || strings.HasPrefix(fn.Name(), "_cgo") {
+			continue
+		}
+		state.Reset()
+		state.checkFunction(fn)
+	}
+
+	if len(state.issues) == 0 {
+		return nil, nil
+	}
+	issues := make([]*issue.Issue, 0, len(state.issues))
+	for _, i := range state.issues {
+		issues = append(issues, i)
+	}
+	return issues, nil
+}
+
+func (s *cgoAuditState) checkFunction(fn *ssa.Function) {
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok {
+				continue
+			}
+			callee := call.Call.StaticCallee()
+			if callee == nil {
+				continue
+			}
+			if name, ok := cgoFuncName(callee); ok {
+				s.checkCgoCall(call, name)
+				continue
+			}
+			if isRawSyscall(callee) {
+				s.addIssue(call.Pos(), fmt.Sprintf("Raw system call %s.%s bypasses the checks of the typed wrappers and should be audited",
+					callee.Pkg.Pkg.Name(), callee.Name()), issue.Low, issue.High)
+			}
+		}
+	}
+}
+
+func (s *cgoAuditState) checkCgoCall(call *ssa.Call, name string) {
+	switch {
+	case cgoAllocators[name]:
+		if !s.escapes(call, 0) && !s.freedOnEveryPath(call) {
+			s.addIssue(call.Pos(), fmt.Sprintf("C.%s allocation is not released by a deferred C.free on every path", name), issue.Medium, issue.Medium)
+		}
+	case cgoHelpers[name]:
+	default:
+		for _, arg := range call.Call.Args {
+			if s.isGoPointer(arg, 0) {
+				s.addIssue(call.Pos(), fmt.Sprintf("Go pointer passed to C function C.%s", name), issue.Medium, issue.Medium)
+				return
+			}
+		}
+		s.addIssue(call.Pos(), fmt.Sprintf("Call to C function C.%s should be audited", name), issue.Low, issue.High)
+	}
+}
+
+func (s *cgoAuditState) addIssue(pos token.Pos, msg string, severity, confidence issue.Score) {
+	if _, found := s.issues[pos]; found {
+		return
+	}
+	s.issues[pos] = newIssue(s.Pass.Analyzer.Name, msg, s.Pass.Fset, pos, severity, confidence)
+}
+
+// freedOnEveryPath reports whether every return of the function allocating
+// with call is preceded by a deferred C.free of the allocation.
+func (s *cgoAuditState) freedOnEveryPath(call *ssa.Call) bool {
+	fn := call.Parent()
+	freeing := make(map[*ssa.BasicBlock]bool)
+	start := call.Block()
+	startFreed := false
+	for _, block := range fn.Blocks {
+		after := block != start
+		for _, instr := range block.Instrs {
+			if instr == ssa.Instruction(call) {
+				after = true
+				continue
+			}
+			if d, ok := instr.(*ssa.Defer); ok && after && s.defersFree(d, call) {
+				freeing[block] = true
+				if block == start {
+					startFreed = true
+				}
+			}
+		}
+	}
+	if startFreed {
+		return true
+	}
+
+	exclude := make([]*ssa.BasicBlock, 0, len(freeing))
+	for block := range freeing {
+		exclude = append(exclude, block)
+	}
+	for _, block := range fn.Blocks {
+		if freeing[block] || len(block.Instrs) == 0 {
+			continue
+		}
+		if _, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return); !ok {
+			continue
+		}
+		if s.Analyzer.IsReachable(start, block, exclude...) {
+			return false
+		}
+	}
+	return true
+}
+
+// defersFree reports whether the deferred call frees alloc. cgo wraps
+// C.free(unsafe.Pointer(p)) into closures checking the pointer first, so the
+// closures run by the deferred call are searched for the free.
+func (s *cgoAuditState) defersFree(d *ssa.Defer, alloc ssa.Value) bool {
+	var funcs []*ssa.Function
+	s.deferredFuncs(d.Call.Value, &funcs, make(map[*ssa.Function]bool), 0)
+	for _, fn := range funcs {
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				call, ok := instr.(*ssa.Call)
+				if !ok {
+					continue
+				}
+				callee := call.Call.StaticCallee()
+				if callee == nil {
+					continue
+				}
+				if name, ok := cgoFuncName(callee); !ok || name != "free" || len(call.Call.Args) == 0 {
+					continue
+				}
+				for _, origin := range s.origins(call.Call.Args[0], 0) {
+					if origin == alloc {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// deferredFuncs collects the functions run by calling v, and the closures
+// they return.
+func (s *cgoAuditState) deferredFuncs(v ssa.Value, funcs *[]*ssa.Function, seen map[*ssa.Function]bool, depth int) {
+	if s.DepthExceeded(depth) {
+		return
+	}
+	var fn *ssa.Function
+	switch v := v.(type) {
+	case *ssa.Function:
+		fn = v
+	case *ssa.MakeClosure:
+		fn, _ = v.Fn.(*ssa.Function)
+	case *ssa.Call:
+		// func() func() { ... }()(): the deferred closure is returned
+		var callees []*ssa.Function
+		s.deferredFuncs(v.Call.Value, &callees, seen, depth+1)
+		for _, callee := range callees {
+			for _, block := range callee.Blocks {
+				if len(block.Instrs) == 0 {
+					continue
+				}
+				if ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return); ok && len(ret.Results) == 1 {
+					s.deferredFuncs(ret.Results[0], funcs, seen, depth+1)
+				}
+			}
+		}
+		return
+	}
+	if fn == nil || seen[fn] {
+		return
+	}
+	seen[fn] = true
+	*funcs = append(*funcs, fn)
+}
+
+// origins returns the values v is copied from, through conversions, phis,
+// captured variables and the local variables they are stored in.
+func (s *cgoAuditState) origins(v ssa.Value, depth int) []ssa.Value {
+	if s.DepthExceeded(depth) {
+		return nil
+	}
+	switch v := v.(type) {
+	case *ssa.Convert:
+		return s.origins(v.X, depth+1)
+	case *ssa.ChangeType:
+		return s.origins(v.X, depth+1)
+	case *ssa.FreeVar:
+		if binding := freeVarBinding(v); binding != nil {
+			return s.origins(binding, depth+1)
+		}
+	case *ssa.Phi:
+		var result []ssa.Value
+		for _, edge := range v.Edges {
+			result = append(result, s.origins(edge, depth+1)...)
+		}
+		return result
+	case *ssa.UnOp:
+		if v.Op == token.MUL {
+			var result []ssa.Value
+			for _, stored := range s.storedValues(v.X, depth+1) {
+				result = append(result, s.origins(stored, depth+1)...)
+			}
+			return result
+		}
+	}
+	return []ssa.Value{v}
+}
+
+// storedValues returns the values stored in the local variable at addr.
+func (s *cgoAuditState) storedValues(addr ssa.Value, depth int) []ssa.Value {
+	if s.DepthExceeded(depth) {
+		return nil
+	}
+	switch addr := addr.(type) {
+	case *ssa.FreeVar:
+		if binding := freeVarBinding(addr); binding != nil {
+			return s.storedValues(binding, depth+1)
+		}
+	case *ssa.Alloc:
+		var result []ssa.Value
+		for _, ref := range safeReferrers(addr) {
+			if store, ok := ref.(*ssa.Store); ok && store.Addr == addr {
+				result = append(result, store.Val)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// isGoPointer reports whether v points into memory allocated by Go.
+func (s *cgoAuditState) isGoPointer(v ssa.Value, depth int) bool {
+	if s.DepthExceeded(depth) {
+		return false
+	}
+	switch v := v.(type) {
+	case *ssa.Alloc, *ssa.MakeSlice, *ssa.MakeMap, *ssa.MakeChan, *ssa.MakeClosure:
+		return true
+	case *ssa.Convert:
+		return s.isGoPointer(v.X, depth+1)
+	case *ssa.ChangeType:
+		return s.isGoPointer(v.X, depth+1)
+	case *ssa.FieldAddr:
+		return s.isGoPointer(v.X, depth+1)
+	case *ssa.IndexAddr:
+		return s.isGoPointer(v.X, depth+1)
+	case *ssa.Slice:
+		return s.isGoPointer(v.X, depth+1)
+	case *ssa.FreeVar:
+		if binding := freeVarBinding(v); binding != nil {
+			return s.isGoPointer(binding, depth+1)
+		}
+	case *ssa.Phi:
+		for _, edge := range v.Edges {
+			if s.isGoPointer(edge, depth+1) {
+				return true
+			}
+		}
+	case *ssa.UnOp:
+		if v.Op == token.MUL {
+			for _, stored := range s.storedValues(v.X, depth+1) {
+				if s.isGoPointer(stored, depth+1) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// escapes reports whether the allocation v leaves the function, so that
+// releasing it is the responsibility of another function.
+func (s *cgoAuditState) escapes(v ssa.Value, depth int) bool {
+	if s.DepthExceeded(depth) {
+		return true
+	}
+	for _, ref := range safeReferrers(v) {
+		switch ref := ref.(type) {
+		case *ssa.Return, *ssa.MakeInterface:
+			return true
+		case *ssa.Store:
+			cell, ok := ref.Addr.(*ssa.Alloc)
+			if !ok || ref.Val != v {
+				return true
+			}
+			for _, use := range safeReferrers(cell) {
+				if load, ok := use.(*ssa.UnOp); ok && load.Op == token.MUL && s.escapes(load, depth+1) {
+					return true
+				}
+			}
+		case *ssa.Convert:
+			if s.escapes(ref, depth+1) {
+				return true
+			}
+		case *ssa.ChangeType:
+			if s.escapes(ref, depth+1) {
+				return true
+			}
+		case *ssa.Call:
+			if callee := ref.Call.StaticCallee(); callee == nil {
+				return true
+			} else if _, ok := cgoFuncName(callee); !ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// freeVarBinding returns the value bound to the free variable by the closure
+// creating its function.
+func freeVarBinding(fv *ssa.FreeVar) ssa.Value {
+	fn := fv.Parent()
+	if fn == nil || fn.Parent() == nil {
+		return nil
+	}
+	index := -1
+	for i, candidate := range fn.FreeVars {
+		if candidate == fv {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil
+	}
+	for _, block := range fn.Parent().Blocks {
+		for _, instr := range block.Instrs {
+			if mc, ok := instr.(*ssa.MakeClosure); ok && mc.Fn == fn && index < len(mc.Bindings) {
+				return mc.Bindings[index]
+			}
+		}
+	}
+	return nil
+}
+
+// cgoFuncName returns the name of the C function called through fn.
+func cgoFuncName(fn *ssa.Function) (string, bool) {
+	if fn.Signature.Recv() != nil || fn.Parent() != nil {
+		return "", false
+	}
+	return strings.CutPrefix(fn.Name(), cgoFuncPrefix)
+}
+
+func isRawSyscall(fn *ssa.Function) bool {
+	if fn.Pkg == nil || fn.Signature.Recv() != nil || !rawSyscallPackages[fn.Pkg.Pkg.Path()] {
+		return false
+	}
+	return strings.HasPrefix(fn.Name(), "Syscall") || strings.HasPrefix(fn.Name(), "RawSyscall")
+}
+
+// cgoImport returns the position of the rewritten import "C" of a file
+// generated by cgo.
+func cgoImport(file *ast.File) (token.Pos, bool) {
+	if !isCgoGenerated(file) {
+		return token.NoPos, false
+	}
+	for _, spec := range file.Imports {
+		if path, err := strconv.Unquote(spec.Path.Value); err == nil && path == "unsafe" && spec.Name != nil && spec.Name.Name == "_" {
+			return spec.Pos(), true
+		}
+	}
+	return token.NoPos, false
+}
+
+func isCgoGenerated(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if comment.Text == "// "+cgoGeneratedHeader {
+				return true
+			}
+		}
+	}
+	return false
+}
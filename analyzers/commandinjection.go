@@ -23,17 +23,8 @@ import (
 // CommandInjection returns a configuration for detecting command injection vulnerabilities.
 func CommandInjection() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
-			// Type sources: tainted when received as parameters
-			{Package: "net/http", Name: "Request", Pointer: true},
-			{Package: "bufio", Name: "Reader", Pointer: true},
-			{Package: "bufio", Name: "Scanner", Pointer: true},
-
-			// Function sources
-			{Package: "os", Name: "Args", IsFunc: true},
-			{Package: "os", Name: "Getenv", IsFunc: true},
-		},
-		Sinks: []taint.Sink{
+		Sources: taint.DefaultSources,
+		Sinks: taint.WithLabels(taint.LabelUserInput, []taint.Sink{
 			// Detect at command creation, not execution (avoids double detection)
 			{Package: "os/exec", Method: "Command"},
 			{Package: "os/exec", Method: "CommandContext"},
@@ -41,11 +32,13 @@ func CommandInjection() taint.Config {
 			{Package: "syscall", Method: "Exec"},
 			{Package: "syscall", Method: "ForkExec"},
 			{Package: "syscall", Method: "StartProcess"},
-		},
+		}),
 		Sanitizers: []taint.Sanitizer{
 			// No general-purpose stdlib sanitizer for command injection.
 			// The proper fix is to use exec.Command with separate args, not shell strings.
 		},
+		// Commands looked up in an allowlist, e.g. if !allowed[cmd] { return }.
+		AllowlistMaps: true,
 	}
 }
 
@@ -33,8 +33,58 @@ const (
 	msgContextBackground = "Goroutine uses context.Background/TODO while request-scoped context is available"
 	msgLostCancel        = "context cancellation function returned by WithCancel/WithTimeout/WithDeadline is not called"
 	msgLoopWithoutDone   = "Long-running loop performs calls without a ctx.Done() cancellation guard"
+
+	msgErrgroupWithoutContext = "errgroup.Group is used without errgroup.WithContext while a request context is available"
+	msgRequestWithoutContext  = "HTTP request ignores the available context, use http.NewRequestWithContext"
+	msgSQLWithoutContext      = "database/sql call ignores the available context, use its Context variant"
+	msgSleepInRequest         = "time.Sleep/time.After in a request path does not honor context cancellation"
+
+	errgroupPkgPath = "golang.org/x/sync/errgroup"
 )
 
+// contextPropagationChecks selects the patterns reported by G118. Each one
+// can be turned on or off in the configuration of the rule:
+//
+//	{"G118": {"sql_without_context": false, "sleep_in_request": true}}
+type contextPropagationChecks struct {
+	lostCancel             bool
+	backgroundGoroutine    bool
+	loopWithoutDone        bool
+	errgroupWithoutContext bool
+	requestWithoutContext  bool
+	sqlWithoutContext      bool
+	sleepInRequest         bool
+}
+
+func newContextPropagationChecks(conf any) contextPropagationChecks {
+	checks := contextPropagationChecks{
+		lostCancel:             true,
+		backgroundGoroutine:    true,
+		loopWithoutDone:        true,
+		errgroupWithoutContext: true,
+		requestWithoutContext:  true,
+		sqlWithoutContext:      true,
+	}
+	settings, ok := conf.(map[string]any)
+	if !ok {
+		return checks
+	}
+	for key, check := range map[string]*bool{
+		"lost_cancel":                  &checks.lostCancel,
+		"background_goroutine":         &checks.backgroundGoroutine,
+		"loop_without_done":            &checks.loopWithoutDone,
+		"errgroup_without_context":     &checks.errgroupWithoutContext,
+		"http_request_without_context": &checks.requestWithoutContext,
+		"sql_without_context":          &checks.sqlWithoutContext,
+		"sleep_in_request":             &checks.sleepInRequest,
+	} {
+		if enabled, ok := settings[key].(bool); ok {
+			*check = enabled
+		}
+	}
+	return checks
+}
+
 func newContextPropagationAnalyzer(id string, description string) *analysis.Analyzer {
 	return &analysis.Analyzer{
 		Name:     id,
@@ -47,13 +97,15 @@ func newContextPropagationAnalyzer(id string, description string) *analysis.Anal
 type contextPropagationState struct {
 	*BaseAnalyzerState
 	ssaFuncs []*ssa.Function
+	checks   contextPropagationChecks
 	issues   map[token.Pos]*issue.Issue
 }
 
-func newContextPropagationState(pass *analysis.Pass, funcs []*ssa.Function) *contextPropagationState {
+func newContextPropagationState(pass *analysis.Pass, funcs []*ssa.Function, checks contextPropagationChecks) *contextPropagationState {
 	return &contextPropagationState{
 		BaseAnalyzerState: NewBaseState(pass),
 		ssaFuncs:          funcs,
+		checks:            checks,
 		issues:            make(map[token.Pos]*issue.Issue),
 	}
 }
@@ -74,7 +126,8 @@ func runContextPropagationAnalysis(pass *analysis.Pass) (any, error) {
 		return nil, err
 	}
 
-	state := newContextPropagationState(pass, ssaResult.SSA.SrcFuncs)
+	checks := newContextPropagationChecks(ssaResult.Config[pass.Analyzer.Name])
+	state := newContextPropagationState(pass, ssaResult.SSA.SrcFuncs, checks)
 	defer state.Release()
 
 	for _, fn := range state.ssaFuncs {
@@ -86,11 +139,21 @@ func runContextPropagationAnalysis(pass *analysis.Pass) (any, error) {
 		ctxValues := collectContextValues(fn)
 
 		if hasRequestContext {
-			state.detectUnsafeGoroutines(fn, ctxValues)
-			state.detectLoopsWithoutCancellationGuard(fn, ctxValues)
+			if checks.backgroundGoroutine {
+				state.detectUnsafeGoroutines(fn, ctxValues)
+			}
+			if checks.loopWithoutDone {
+				state.detectLoopsWithoutCancellationGuard(fn, ctxValues)
+			}
+			if checks.errgroupWithoutContext {
+				state.detectErrgroupWithoutContext(fn)
+			}
+			state.detectCallsIgnoringContext(fn)
 		}
 
-		state.detectLostCancel(fn)
+		if checks.lostCancel {
+			state.detectLostCancel(fn)
+		}
 	}
 
 	if len(state.issues) == 0 {
@@ -286,6 +349,145 @@ func (s *contextPropagationState) detectLoopsWithoutCancellationGuard(fn *ssa.Fu
 	}
 }
 
+// detectErrgroupWithoutContext reports groups of goroutines created as a plain
+// errgroup.Group, whose goroutines are not cancelled with the request.
+// Groups created by errgroup.WithContext, or received from elsewhere, are not
+// reported.
+func (s *contextPropagationState) detectErrgroupWithoutContext(fn *ssa.Function) {
+	reported := make(map[ssa.Value]bool)
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			callInstr, ok := instr.(ssa.CallInstruction)
+			if !ok {
+				continue
+			}
+			common := callInstr.Common()
+			if !isErrgroupGoCall(common) || len(common.Args) == 0 {
+				continue
+			}
+			group, ok := common.Args[0].(*ssa.Alloc)
+			if !ok || reported[group] {
+				continue
+			}
+			reported[group] = true
+			s.addIssue(instr.Pos(), msgErrgroupWithoutContext, issue.Medium, issue.Medium)
+		}
+	}
+}
+
+// detectCallsIgnoringContext reports the calls of a function with a request
+// context that have a context-aware variant: http.NewRequest and the http.Get
+// family, database/sql calls without their Context variant, and time.Sleep or
+// time.After in a function that never checks ctx.Done().
+func (s *contextPropagationState) detectCallsIgnoringContext(fn *ssa.Function) {
+	checksDone := false
+	var timers []ssa.CallInstruction
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			callInstr, ok := instr.(ssa.CallInstruction)
+			if !ok {
+				continue
+			}
+			common := callInstr.Common()
+			if common == nil {
+				continue
+			}
+			switch {
+			case isContextDoneCall(common):
+				checksDone = true
+			case s.checks.requestWithoutContext && isHTTPCallWithoutContext(common):
+				s.addIssue(instr.Pos(), msgRequestWithoutContext, issue.Medium, issue.High)
+			case s.checks.sqlWithoutContext && isSQLCallWithoutContext(common):
+				s.addIssue(instr.Pos(), msgSQLWithoutContext, issue.Medium, issue.High)
+			case s.checks.sleepInRequest && isTimerCall(common):
+				timers = append(timers, callInstr)
+			}
+		}
+	}
+	if checksDone {
+		return
+	}
+	for _, timer := range timers {
+		s.addIssue(timer.Pos(), msgSleepInRequest, issue.Low, issue.Medium)
+	}
+}
+
+func isErrgroupGoCall(common *ssa.CallCommon) bool {
+	callee := common.StaticCallee()
+	if callee == nil || callee.Signature == nil || callee.Signature.Recv() == nil {
+		return false
+	}
+	if callee.Name() != "Go" && callee.Name() != "TryGo" {
+		return false
+	}
+	ptr, ok := callee.Signature.Recv().Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Name() == "Group" && obj.Pkg() != nil && obj.Pkg().Path() == errgroupPkgPath
+}
+
+func isHTTPCallWithoutContext(common *ssa.CallCommon) bool {
+	callee := common.StaticCallee()
+	if callee == nil || callee.Signature == nil || callee.Pkg == nil || callee.Pkg.Pkg == nil || callee.Pkg.Pkg.Path() != httpPkgPath {
+		return false
+	}
+	if recv := callee.Signature.Recv(); recv != nil && !isHTTPClientPointerType(recv.Type()) {
+		return false
+	}
+	switch callee.Name() {
+	case "NewRequest", "Get", "Head", "Post", "PostForm":
+		// Package functions and the methods of *http.Client alike
+		return true
+	}
+	return false
+}
+
+func isHTTPClientPointerType(t types.Type) bool {
+	ptr, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Name() == "Client" && obj.Pkg() != nil && obj.Pkg().Path() == httpPkgPath
+}
+
+func isSQLCallWithoutContext(common *ssa.CallCommon) bool {
+	callee := common.StaticCallee()
+	if callee == nil || callee.Pkg == nil || callee.Pkg.Pkg == nil || callee.Pkg.Pkg.Path() != "database/sql" {
+		return false
+	}
+	if callee.Signature == nil || callee.Signature.Recv() == nil {
+		return false
+	}
+	switch callee.Name() {
+	case "Query", "QueryRow", "Exec", "Prepare", "Begin", "Ping", "Stmt":
+		// Methods of *DB, *Tx and *Stmt, which all have a Context variant
+		return true
+	}
+	return false
+}
+
+func isTimerCall(common *ssa.CallCommon) bool {
+	callee := common.StaticCallee()
+	if callee == nil || callee.Pkg == nil || callee.Pkg.Pkg == nil || callee.Pkg.Pkg.Path() != "time" {
+		return false
+	}
+	if callee.Signature != nil && callee.Signature.Recv() != nil {
+		return false
+	}
+	return callee.Name() == "Sleep" || callee.Name() == "After"
+}
+
 type blockFeatures struct {
 	hasDoneGuard bool
 	hasBlocking  bool
@@ -0,0 +1,150 @@
+package analyzers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// fakeContextPropagationPackages stand in for the packages used by the
+// errgroup fixture, which are not available to the test build.
+var fakeContextPropagationPackages = map[string]string{
+	"context": `package context
+
+type Context interface{ Done() <-chan struct{} }
+`,
+	"golang.org/x/sync/errgroup": `package errgroup
+
+import "context"
+
+type Group struct{ n int }
+
+func WithContext(ctx context.Context) (*Group, context.Context) { return &Group{}, ctx }
+
+func (g *Group) Go(f func() error)         {}
+func (g *Group) TryGo(f func() error) bool { return true }
+func (g *Group) Wait() error               { return nil }
+`,
+}
+
+type fakeImporter struct {
+	fset *token.FileSet
+	pkgs map[string]*types.Package
+}
+
+func (imp *fakeImporter) Import(path string) (*types.Package, error) {
+	if pkg, ok := imp.pkgs[path]; ok {
+		return pkg, nil
+	}
+	src, ok := fakeContextPropagationPackages[path]
+	if !ok {
+		return nil, fmt.Errorf("unknown package %q", path)
+	}
+	file, err := parser.ParseFile(imp.fset, path+".go", src, 0)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := (&types.Config{Importer: imp}).Check(path, imp.fset, []*ast.File{file}, nil)
+	if err != nil {
+		return nil, err
+	}
+	imp.pkgs[path] = pkg
+	return pkg, nil
+}
+
+func TestDetectErrgroupWithoutContext(t *testing.T) {
+	t.Parallel()
+
+	src := `package p
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+func plain(ctx context.Context) error {
+	var g errgroup.Group
+	g.Go(func() error { return nil })
+	g.Go(func() error { return nil })
+	return g.Wait()
+}
+
+func allocated(ctx context.Context) error {
+	g := new(errgroup.Group)
+	g.TryGo(func() error { return nil })
+	return g.Wait()
+}
+
+func withContext(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error { <-ctx.Done(); return nil })
+	return g.Wait()
+}
+
+func received(ctx context.Context, g *errgroup.Group) {
+	g.Go(func() error { return nil })
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue), Defs: make(map[*ast.Ident]types.Object),
+		Uses: make(map[*ast.Ident]types.Object), Implicits: make(map[ast.Node]types.Object),
+		Scopes: make(map[ast.Node]*types.Scope), Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	imp := &fakeImporter{fset: fset, pkgs: make(map[string]*types.Package)}
+	pkg, err := (&types.Config{Importer: imp}).Check("p", fset, []*ast.File{file}, info)
+	if err != nil {
+		t.Fatalf("type-check: %v", err)
+	}
+	prog := ssa.NewProgram(fset, 0)
+	for _, dep := range imp.pkgs {
+		prog.CreatePackage(dep, nil, nil, true)
+	}
+	ssaPkg := prog.CreatePackage(pkg, []*ast.File{file}, info, false)
+	ssaPkg.Build()
+
+	pass := &analysis.Pass{Analyzer: &analysis.Analyzer{Name: "G118"}, Fset: fset}
+	state := newContextPropagationState(pass, nil, newContextPropagationChecks(nil))
+	defer state.Release()
+	for _, name := range []string{"plain", "allocated", "withContext", "received"} {
+		state.detectErrgroupWithoutContext(ssaPkg.Func(name))
+	}
+
+	var lines []int
+	for pos := range state.issues {
+		lines = append(lines, fset.Position(pos).Line)
+	}
+	sort.Ints(lines)
+	// Once per group, at its first goroutine
+	if want := []int{11, 18}; fmt.Sprint(lines) != fmt.Sprint(want) {
+		t.Fatalf("expected issues at lines %v, got %v", want, lines)
+	}
+}
+
+func TestContextPropagationChecksConfig(t *testing.T) {
+	t.Parallel()
+
+	checks := newContextPropagationChecks(map[string]any{
+		"sleep_in_request":         true,
+		"errgroup_without_context": false,
+		"lost_cancel":              "no",
+	})
+	if !checks.sleepInRequest || checks.errgroupWithoutContext {
+		t.Fatalf("configured checks not applied: %+v", checks)
+	}
+	if !checks.lostCancel || !checks.sqlWithoutContext {
+		t.Fatalf("expected the other checks to keep their default: %+v", checks)
+	}
+}
@@ -47,6 +47,9 @@ func runCORSBypassPatternAnalysis(pass *analysis.Pass) (any, error) {
 		return nil, err
 	}
 
+	state := NewBaseState(pass)
+	defer state.Release()
+
 	issuesByPos := make(map[token.Pos]*issue.Issue)
 
 	for _, fn := range collectAnalyzerFunctions(ssaResult.SSA.SrcFuncs) {
@@ -73,14 +76,14 @@ func runCORSBypassPatternAnalysis(pass *analysis.Pass) (any, error) {
 				}
 
 				patternArg := common.Args[1]
-				if pattern, ok := extractStringValue(patternArg, 0); ok {
+				if pattern, ok := extractStringValue(state, patternArg, 0); ok {
 					if isOverbroadBypassPattern(pattern) {
 						addG121Issue(issuesByPos, pass, instr.Pos(), msgOverbroadBypassPattern, issue.High, issue.High)
 					}
 					continue
 				}
 
-				if requestParam != nil && valueDependsOn(patternArg, requestParam, 0) {
+				if requestParam != nil && state.valueDependsOn(patternArg, requestParam, 0) {
 					addG121Issue(issuesByPos, pass, instr.Pos(), msgRequestBypassPattern, issue.High, issue.Medium)
 				}
 			}
@@ -155,8 +158,8 @@ func isCrossOriginProtectionType(t types.Type) bool {
 	return pkg != nil && pkg.Path() == "net/http"
 }
 
-func extractStringValue(v ssa.Value, depth int) (string, bool) {
-	if v == nil || depth > MaxDepth {
+func extractStringValue(s *BaseAnalyzerState, v ssa.Value, depth int) (string, bool) {
+	if v == nil || s.DepthExceeded(depth) {
 		return "", false
 	}
 
@@ -166,18 +169,18 @@ func extractStringValue(v ssa.Value, depth int) (string, bool) {
 
 	switch x := v.(type) {
 	case *ssa.ChangeType:
-		return extractStringValue(x.X, depth+1)
+		return extractStringValue(s, x.X, depth+1)
 	case *ssa.MakeInterface:
-		return extractStringValue(x.X, depth+1)
+		return extractStringValue(s, x.X, depth+1)
 	case *ssa.TypeAssert:
-		return extractStringValue(x.X, depth+1)
+		return extractStringValue(s, x.X, depth+1)
 	case *ssa.Phi:
 		if len(x.Edges) == 0 {
 			return "", false
 		}
 		var candidate string
 		for _, edge := range x.Edges {
-			val, ok := extractStringValue(edge, depth+1)
+			val, ok := extractStringValue(s, edge, depth+1)
 			if !ok {
 				return "", false
 			}
@@ -22,14 +22,16 @@ type dependencyKey struct {
 }
 
 type dependencyChecker struct {
-	memo     map[dependencyKey]bool
-	visiting map[dependencyKey]struct{}
+	memo          map[dependencyKey]bool
+	visiting      map[dependencyKey]struct{}
+	depthExceeded func(depth int) bool
 }
 
-func newDependencyChecker() *dependencyChecker {
+func newDependencyChecker(depthExceeded func(depth int) bool) *dependencyChecker {
 	return &dependencyChecker{
-		memo:     make(map[dependencyKey]bool),
-		visiting: make(map[dependencyKey]struct{}),
+		memo:          make(map[dependencyKey]bool),
+		visiting:      make(map[dependencyKey]struct{}),
+		depthExceeded: depthExceeded,
 	}
 }
 
@@ -38,7 +40,7 @@ func (c *dependencyChecker) dependsOn(value ssa.Value, target ssa.Value) bool {
 }
 
 func (c *dependencyChecker) dependsOnDepth(value ssa.Value, target ssa.Value, depth int) bool {
-	if value == nil || target == nil || depth > MaxDepth {
+	if value == nil || target == nil || c.depthExceeded(depth) {
 		return false
 	}
 	if value == target {
@@ -3,15 +3,19 @@ package analyzers
 import (
 	"go/constant"
 	"go/types"
+	"slices"
 	"testing"
 
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/ssa"
+
+	"github.com/securego/gosec/v2/internal/ssautil"
 )
 
 func TestDependencyCheckerHandlesPhiCycleWithoutTarget(t *testing.T) {
 	t.Parallel()
 
-	checker := newDependencyChecker()
+	checker := newDependencyChecker((&BaseAnalyzerState{}).DepthExceeded)
 	target := ssa.NewConst(constant.MakeInt64(42), types.Typ[types.Int])
 
 	phiA := &ssa.Phi{}
@@ -27,7 +31,7 @@ func TestDependencyCheckerHandlesPhiCycleWithoutTarget(t *testing.T) {
 func TestDependencyCheckerFindsTargetInPhiCycle(t *testing.T) {
 	t.Parallel()
 
-	checker := newDependencyChecker()
+	checker := newDependencyChecker((&BaseAnalyzerState{}).DepthExceeded)
 	target := ssa.NewConst(constant.MakeInt64(7), types.Typ[types.Int])
 
 	phiA := &ssa.Phi{}
@@ -54,7 +58,7 @@ func TestValueDependsOnHandlesPhiCycleWithoutTarget(t *testing.T) {
 	phiA.Edges = []ssa.Value{phiB}
 	phiB.Edges = []ssa.Value{phiA}
 
-	if valueDependsOn(phiA, target, 0) {
+	if (&BaseAnalyzerState{}).valueDependsOn(phiA, target, 0) {
 		t.Fatal("expected false for Phi cycle with no path to target")
 	}
 }
@@ -69,11 +73,11 @@ func TestValueDependsOnFindsTargetInPhiCycle(t *testing.T) {
 	phiA.Edges = []ssa.Value{phiB, target}
 	phiB.Edges = []ssa.Value{phiA}
 
-	if !valueDependsOn(phiA, target, 0) {
+	if !(&BaseAnalyzerState{}).valueDependsOn(phiA, target, 0) {
 		t.Fatal("expected true when cycle has a path to target")
 	}
 
-	if !valueDependsOn(phiA, target, 0) {
+	if !(&BaseAnalyzerState{}).valueDependsOn(phiA, target, 0) {
 		t.Fatal("expected stable result on repeated call")
 	}
 }
@@ -86,7 +90,29 @@ func TestValueDependsOnSelfReferentialPhi(t *testing.T) {
 	phi := &ssa.Phi{}
 	phi.Edges = []ssa.Value{phi}
 
-	if valueDependsOn(phi, target, 0) {
+	if (&BaseAnalyzerState{}).valueDependsOn(phi, target, 0) {
 		t.Fatal("expected false for self-referential Phi with no path to target")
 	}
 }
+
+func TestValueDependsOnRecordsDepthTruncation(t *testing.T) {
+	t.Parallel()
+
+	target := ssa.NewConst(constant.MakeInt64(1), types.Typ[types.Int])
+
+	// target reached through a chain of three conversions
+	var value ssa.Value = target
+	for range 3 {
+		value = &ssa.ChangeType{X: value}
+	}
+
+	budget := &ssautil.Budget{MaxDepth: 1}
+	state := &BaseAnalyzerState{Pass: &analysis.Pass{Analyzer: &analysis.Analyzer{Name: "G999"}}, Budget: budget}
+	if state.valueDependsOn(value, target, 0) {
+		t.Fatal("expected the dependency beyond the depth budget to be missed")
+	}
+	truncations := budget.Truncations()
+	if len(truncations) != 1 || !slices.Contains(truncations[0].Reasons, ssautil.TruncatedMaxDepth) {
+		t.Fatalf("expected a depth truncation, got %v", truncations)
+	}
+}
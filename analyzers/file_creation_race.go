@@ -0,0 +1,321 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"go/constant"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/securego/gosec/v2/internal/ssautil"
+	"github.com/securego/gosec/v2/issue"
+)
+
+const (
+	msgStatThenCreate = "File created after checking the path with os.Stat races with other processes; open it with O_CREATE|O_EXCL instead"
+	msgTempOpenFile   = "File opened with O_CREATE in a shared temporary directory without O_EXCL or O_NOFOLLOW may follow a planted symlink"
+	msgTempMkdirAll   = "File created in a directory made by os.MkdirAll in a shared temporary directory, which may already exist and belong to another user; use os.MkdirTemp"
+)
+
+// sharedTempPath matches the world-writable temporary directories, like G303.
+var sharedTempPath = regexp.MustCompile(`^(/(usr|var))?/tmp(/.*)?$`)
+
+func newFileCreationRaceAnalyzer(id string, description string) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:     id,
+		Doc:      description,
+		Run:      runFileCreationRace,
+		Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	}
+}
+
+type fileCreationRaceState struct {
+	*BaseAnalyzerState
+	flags  openFlags
+	issues map[token.Pos]*issue.Issue
+}
+
+// openFlags are the values of the open flags for the platform of the package.
+type openFlags struct {
+	create, excl, nofollow int64
+	known                  bool
+}
+
+func runFileCreationRace(pass *analysis.Pass) (any, error) {
+	ssaResult, err := ssautil.GetSSAResult(pass)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &fileCreationRaceState{
+		BaseAnalyzerState: NewBaseState(pass),
+		flags:             lookupOpenFlags(pass.Pkg),
+		issues:            make(map[token.Pos]*issue.Issue),
+	}
+	defer state.Release()
+
+	for _, fn := range collectAnalyzerFunctions(ssaResult.SSA.SrcFuncs) {
+		state.Reset()
+		state.checkFunction(fn)
+	}
+
+	if len(state.issues) == 0 {
+		return nil, nil
+	}
+	issues := make([]*issue.Issue, 0, len(state.issues))
+	for _, i := range state.issues {
+		issues = append(issues, i)
+	}
+	return issues, nil
+}
+
+func (s *fileCreationRaceState) checkFunction(fn *ssa.Function) {
+	var stats, mkdirs, creates []*ssa.Call
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok || len(call.Call.Args) == 0 {
+				continue
+			}
+			switch osCallName(call) {
+			case "Stat", "Lstat":
+				stats = append(stats, call)
+			case "MkdirAll":
+				if s.isSharedTempPath(call.Call.Args[0], 0) {
+					mkdirs = append(mkdirs, call)
+				}
+			case "Create", "WriteFile":
+				creates = append(creates, call)
+			case "OpenFile":
+				if s.flagsCreateWithoutExcl(call) {
+					creates = append(creates, call)
+				}
+			}
+		}
+	}
+
+	for _, create := range creates {
+		path := create.Call.Args[0]
+		switch {
+		case s.anyPrecedes(stats, create, func(stat *ssa.Call) bool { return samePath(stat.Call.Args[0], path) }):
+			s.addIssue(create.Pos(), msgStatThenCreate, issue.High)
+		case osCallName(create) == "OpenFile" && !s.hasFlag(create, s.flags.nofollow) && s.isSharedTempPath(path, 0):
+			s.addIssue(create.Pos(), msgTempOpenFile, issue.Medium)
+		case s.anyPrecedes(mkdirs, create, func(mkdir *ssa.Call) bool { return s.isRootedAt(path, mkdir.Call.Args[0], 0) }):
+			s.addIssue(create.Pos(), msgTempMkdirAll, issue.Medium)
+		}
+	}
+}
+
+func (s *fileCreationRaceState) addIssue(pos token.Pos, msg string, confidence issue.Score) {
+	if _, found := s.issues[pos]; found {
+		return
+	}
+	s.issues[pos] = newIssue(s.Pass.Analyzer.Name, msg, s.Pass.Fset, pos, issue.Medium, confidence)
+}
+
+// anyPrecedes reports whether one of the calls matching the predicate can run
+// before call.
+func (s *fileCreationRaceState) anyPrecedes(calls []*ssa.Call, call *ssa.Call, match func(*ssa.Call) bool) bool {
+	for _, candidate := range calls {
+		if !match(candidate) {
+			continue
+		}
+		if candidate.Block() == call.Block() {
+			if instrIndex(candidate) < instrIndex(call) {
+				return true
+			}
+			continue
+		}
+		if s.Analyzer.IsReachable(candidate.Block(), call.Block()) {
+			return true
+		}
+	}
+	return false
+}
+
+// flagsCreateWithoutExcl reports whether the constant flags of an os.OpenFile
+// call create the file without O_EXCL.
+func (s *fileCreationRaceState) flagsCreateWithoutExcl(call *ssa.Call) bool {
+	if !s.flags.known || len(call.Call.Args) < 2 {
+		return false
+	}
+	return s.hasFlag(call, s.flags.create) && !s.hasFlag(call, s.flags.excl)
+}
+
+func (s *fileCreationRaceState) hasFlag(call *ssa.Call, flag int64) bool {
+	flags, ok := GetConstantInt64(call.Call.Args[1])
+	return ok && flag != 0 && flags&flag == flag
+}
+
+// isSharedTempPath reports whether the path v is in a world-writable
+// temporary directory.
+func (s *fileCreationRaceState) isSharedTempPath(v ssa.Value, depth int) bool {
+	if s.DepthExceeded(depth) {
+		return false
+	}
+	if str := extractStringConst(v); str != "" {
+		return sharedTempPath.MatchString(str)
+	}
+	if prefix := pathPrefix(v); prefix != nil {
+		return s.isSharedTempPath(prefix, depth+1)
+	}
+	if call, ok := v.(*ssa.Call); ok {
+		return osCallName(call) == "TempDir"
+	}
+	return false
+}
+
+// isRootedAt reports whether the path v is built from dir.
+func (s *fileCreationRaceState) isRootedAt(v, dir ssa.Value, depth int) bool {
+	if s.DepthExceeded(depth) {
+		return false
+	}
+	if samePath(v, dir) {
+		return true
+	}
+	if prefix := pathPrefix(v); prefix != nil {
+		return s.isRootedAt(prefix, dir, depth+1)
+	}
+	return false
+}
+
+// pathPrefix returns the value a path starts with: the first element of
+// filepath.Join or path.Join, the left operand of a concatenation, or the
+// format of fmt.Sprintf.
+func pathPrefix(v ssa.Value) ssa.Value {
+	switch v := v.(type) {
+	case *ssa.BinOp:
+		if v.Op == token.ADD {
+			return v.X
+		}
+	case *ssa.Call:
+		callee := v.Call.StaticCallee()
+		if callee == nil || callee.Pkg == nil || len(v.Call.Args) == 0 {
+			return nil
+		}
+		switch path := callee.Pkg.Pkg.Path(); {
+		case (path == "path/filepath" || path == "path") && callee.Name() == "Join":
+			return firstVariadicArg(v.Call.Args[0])
+		case path == "fmt" && callee.Name() == "Sprintf":
+			if format := extractStringConst(v.Call.Args[0]); format != "" {
+				// Only the constant part before the first verb is known
+				if i := strings.IndexByte(format, '%'); i >= 0 {
+					format = format[:i]
+				}
+				return ssa.NewConst(constant.MakeString(format), types.Typ[types.String])
+			}
+		}
+	}
+	return nil
+}
+
+// firstVariadicArg returns the first element of the slice built for a
+// variadic call.
+func firstVariadicArg(v ssa.Value) ssa.Value {
+	slice, ok := v.(*ssa.Slice)
+	if !ok {
+		return nil
+	}
+	array, ok := slice.X.(*ssa.Alloc)
+	if !ok {
+		return nil
+	}
+	for _, ref := range safeReferrers(array) {
+		addr, ok := ref.(*ssa.IndexAddr)
+		if !ok {
+			continue
+		}
+		if index, ok := GetConstantInt64(addr.Index); !ok || index != 0 {
+			continue
+		}
+		for _, use := range safeReferrers(addr) {
+			if store, ok := use.(*ssa.Store); ok && store.Addr == addr {
+				return store.Val
+			}
+		}
+	}
+	return nil
+}
+
+// samePath reports whether a and b are the same path value.
+func samePath(a, b ssa.Value) bool {
+	if a == b {
+		return true
+	}
+	ca, okA := a.(*ssa.Const)
+	cb, okB := b.(*ssa.Const)
+	return okA && okB && ca.Value != nil && cb.Value != nil && constant.Compare(ca.Value, token.EQL, cb.Value)
+}
+
+// osCallName returns the name of the os function called, if any.
+func osCallName(call *ssa.Call) string {
+	callee := call.Call.StaticCallee()
+	if callee == nil || callee.Pkg == nil || callee.Signature.Recv() != nil {
+		return ""
+	}
+	if path := callee.Pkg.Pkg.Path(); path != "os" && !(path == "io/ioutil" && callee.Name() == "WriteFile") {
+		return ""
+	}
+	return callee.Name()
+}
+
+func instrIndex(instr ssa.Instruction) int {
+	for i, candidate := range instr.Block().Instrs {
+		if candidate == instr {
+			return i
+		}
+	}
+	return -1
+}
+
+// lookupOpenFlags resolves the open flags in the os and syscall packages
+// imported by pkg, which have the values of the platform being analyzed.
+func lookupOpenFlags(pkg *types.Package) openFlags {
+	var flags openFlags
+	visited := make(map[*types.Package]bool)
+	queue := []*types.Package{pkg}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == nil || visited[current] {
+			continue
+		}
+		visited[current] = true
+		switch current.Path() {
+		case "os":
+			flags.create, flags.known = lookupIntConst(current, "O_CREATE")
+			flags.excl, _ = lookupIntConst(current, "O_EXCL")
+		case "syscall":
+			flags.nofollow, _ = lookupIntConst(current, "O_NOFOLLOW")
+		}
+		queue = append(queue, current.Imports()...)
+	}
+	return flags
+}
+
+func lookupIntConst(pkg *types.Package, name string) (int64, bool) {
+	c, ok := pkg.Scope().Lookup(name).(*types.Const)
+	if !ok {
+		return 0, false
+	}
+	return constant.Int64Val(constant.ToInt(c.Val()))
+}
@@ -28,17 +28,15 @@ import (
 // standard library (see net/http.Request.ParseForm documentation).
 func FormParsingLimits() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
-			{Package: "net/http", Name: "Request", Pointer: true},
-		},
-		Sinks: []taint.Sink{
+		Sources: taint.DefaultSources,
+		Sinks: taint.WithLabels(taint.LabelHTTPInput, []taint.Sink{
 			// ParseMultipartForm reads the entire body into memory/disk with
 			// no automatic cap — the caller-supplied maxMemory only limits the
 			// in-memory portion while the total can be maxMemory + 10 MiB.
 			// Without http.MaxBytesReader the full body is consumed.
 			// CheckArgs: [0] checks only the receiver (*http.Request).
 			{Package: "net/http", Receiver: "Request", Method: "ParseMultipartForm", Pointer: true, CheckArgs: []int{0}},
-		},
+		}),
 		Sanitizers: []taint.Sanitizer{},
 	}
 }
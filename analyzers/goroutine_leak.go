@@ -0,0 +1,327 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/securego/gosec/v2/internal/ssautil"
+	"github.com/securego/gosec/v2/issue"
+)
+
+const msgGoroutineLeak = "Goroutine sends on an unbuffered channel that the function can return without receiving from, leaking the goroutine"
+
+func newGoroutineLeakAnalyzer(id string, description string) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:     id,
+		Doc:      description,
+		Run:      runGoroutineLeakAnalysis,
+		Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	}
+}
+
+type goroutineLeakState struct {
+	*BaseAnalyzerState
+	issues map[token.Pos]*issue.Issue
+}
+
+func runGoroutineLeakAnalysis(pass *analysis.Pass) (any, error) {
+	ssaResult, err := ssautil.GetSSAResult(pass)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &goroutineLeakState{
+		BaseAnalyzerState: NewBaseState(pass),
+		issues:            make(map[token.Pos]*issue.Issue),
+	}
+	defer state.Release()
+
+	for _, fn := range collectAnalyzerFunctions(ssaResult.SSA.SrcFuncs) {
+		state.checkFunction(fn)
+	}
+
+	if len(state.issues) == 0 {
+		return nil, nil
+	}
+	issues := make([]*issue.Issue, 0, len(state.issues))
+	for _, i := range state.issues {
+		issues = append(issues, i)
+	}
+	return issues, nil
+}
+
+// checkFunction reports the goroutines started by fn that send on an
+// unbuffered channel created by fn, when fn has a return path on which the
+// channel is never received from.
+func (s *goroutineLeakState) checkFunction(fn *ssa.Function) {
+	if fn == nil || len(fn.Blocks) == 0 {
+		return
+	}
+
+	var inLoop map[*ssa.BasicBlock]bool
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			ch, ok := instr.(*ssa.MakeChan)
+			if !ok || !isUnbufferedChan(ch) {
+				continue
+			}
+			uses, ok := channelUses(ch)
+			if !ok || len(uses.senders) == 0 {
+				continue
+			}
+			if inLoop == nil {
+				inLoop = loopBlocks(fn)
+			}
+			for _, goInstr := range uses.senders {
+				// Goroutines started in a loop are usually matched by a
+				// receiving loop, which this analysis cannot count.
+				if inLoop[goInstr.Block()] {
+					continue
+				}
+				if s.returnsWithoutReceive(fn, goInstr, uses.receives) {
+					s.addIssue(goInstr.Pos())
+				}
+			}
+		}
+	}
+}
+
+func (s *goroutineLeakState) addIssue(pos token.Pos) {
+	if pos == token.NoPos {
+		return
+	}
+	if _, found := s.issues[pos]; found {
+		return
+	}
+	s.issues[pos] = newIssue(s.Pass.Analyzer.Name, msgGoroutineLeak, s.Pass.Fset, pos, issue.Medium, issue.Medium)
+}
+
+// returnsWithoutReceive reports whether a return of fn is reachable from the
+// go statement without passing through a block that receives from the channel.
+func (s *goroutineLeakState) returnsWithoutReceive(fn *ssa.Function, goInstr *ssa.Go, receives map[*ssa.BasicBlock][]ssa.Instruction) bool {
+	start := goInstr.Block()
+	if receivesAfter(start, goInstr, receives[start]) {
+		return false
+	}
+
+	exclude := make([]*ssa.BasicBlock, 0, len(receives))
+	for block := range receives {
+		if block != start {
+			exclude = append(exclude, block)
+		}
+	}
+	for _, block := range fn.Blocks {
+		if len(block.Instrs) == 0 {
+			continue
+		}
+		if _, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return); !ok {
+			continue
+		}
+		if len(receives[block]) > 0 && block != start {
+			continue
+		}
+		if s.Analyzer.IsReachable(start, block, exclude...) {
+			return true
+		}
+	}
+	return false
+}
+
+// receivesAfter reports whether one of the receives of the block of the go
+// statement follows it.
+func receivesAfter(block *ssa.BasicBlock, goInstr *ssa.Go, receives []ssa.Instruction) bool {
+	if len(receives) == 0 {
+		return false
+	}
+	after := false
+	for _, instr := range block.Instrs {
+		if instr == ssa.Instruction(goInstr) {
+			after = true
+			continue
+		}
+		if after && slices.Contains(receives, instr) {
+			return true
+		}
+	}
+	return false
+}
+
+// chanUses are the uses of a channel by the function creating it.
+type chanUses struct {
+	// senders are the go statements whose goroutine sends on the channel.
+	senders []*ssa.Go
+	// receives are the instructions that always receive from the channel,
+	// receive operations and selects whose only case receives from it, by block.
+	receives map[*ssa.BasicBlock][]ssa.Instruction
+}
+
+// channelUses collects the uses of ch. It fails when the channel escapes the
+// function, since it may then be received from elsewhere.
+func channelUses(ch *ssa.MakeChan) (chanUses, bool) {
+	uses := chanUses{receives: make(map[*ssa.BasicBlock][]ssa.Instruction)}
+	return uses, uses.collect(ch)
+}
+
+// collect adds the uses of v, a value holding the channel, and reports whether
+// they all keep the channel local.
+func (uses *chanUses) collect(v ssa.Value) bool {
+	for _, ref := range safeReferrers(v) {
+		switch r := ref.(type) {
+		case *ssa.DebugRef, *ssa.Send:
+		case *ssa.UnOp:
+			if r.Op != token.ARROW {
+				return false
+			}
+			uses.receives[r.Block()] = append(uses.receives[r.Block()], r)
+		case *ssa.Select:
+			if r.Blocking && len(r.States) == 1 && r.States[0].Dir == types.RecvOnly {
+				uses.receives[r.Block()] = append(uses.receives[r.Block()], r)
+			}
+		case *ssa.Store:
+			// A variable captured by a closure lives in a heap cell
+			cell, ok := r.Addr.(*ssa.Alloc)
+			if !ok || r.Val != v || !uses.collectCell(cell, r) {
+				return false
+			}
+		case *ssa.Call:
+			if builtin, ok := r.Call.Value.(*ssa.Builtin); !ok || (builtin.Name() != "close" && builtin.Name() != "len" && builtin.Name() != "cap") {
+				return false
+			}
+		case *ssa.MakeClosure:
+			if !uses.collectClosure(r, v) {
+				return false
+			}
+		case *ssa.Go:
+			callee := r.Call.StaticCallee()
+			if callee == nil {
+				return false
+			}
+			for i, arg := range r.Call.Args {
+				if arg == v && i < len(callee.Params) && sendsOn(callee, callee.Params[i]) {
+					uses.senders = append(uses.senders, r)
+					break
+				}
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// collectCell adds the uses of the channel loaded from cell, which must not be
+// assigned anything but the channel stored by store.
+func (uses *chanUses) collectCell(cell *ssa.Alloc, store *ssa.Store) bool {
+	for _, ref := range safeReferrers(cell) {
+		switch r := ref.(type) {
+		case *ssa.DebugRef:
+		case *ssa.Store:
+			if r != store {
+				return false
+			}
+		case *ssa.UnOp:
+			if r.Op != token.MUL || !uses.collect(r) {
+				return false
+			}
+		case *ssa.MakeClosure:
+			if !uses.collectClosure(r, cell) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// collectClosure records the go statement starting the closure mc when it
+// sends on the channel bound from v. The closure must only start a goroutine.
+func (uses *chanUses) collectClosure(mc *ssa.MakeClosure, v ssa.Value) bool {
+	goInstr, ok := goOfClosure(mc)
+	if !ok {
+		return false
+	}
+	fn, _ := mc.Fn.(*ssa.Function)
+	idx := bindingIndex(mc, v)
+	if fn == nil || idx < 0 || idx >= len(fn.FreeVars) {
+		return false
+	}
+	if sendsOn(fn, fn.FreeVars[idx]) {
+		uses.senders = append(uses.senders, goInstr)
+	}
+	return true
+}
+
+// goOfClosure returns the go statement starting the closure, if the closure
+// is only used to start a goroutine.
+func goOfClosure(mc *ssa.MakeClosure) (*ssa.Go, bool) {
+	refs := safeReferrers(mc)
+	if len(refs) != 1 {
+		return nil, false
+	}
+	goInstr, ok := refs[0].(*ssa.Go)
+	return goInstr, ok && goInstr.Call.Value == mc
+}
+
+func bindingIndex(mc *ssa.MakeClosure, v ssa.Value) int {
+	for i, binding := range mc.Bindings {
+		if binding == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// sendsOn reports whether fn sends on the channel ch, or on the channel held
+// by the cell ch, with a plain send, which blocks until a receiver is ready.
+// Sends in a select can give up.
+func sendsOn(fn *ssa.Function, ch ssa.Value) bool {
+	for _, ref := range safeReferrers(ch) {
+		switch r := ref.(type) {
+		case *ssa.Send:
+			if r.Chan == ch && r.Parent() == fn {
+				return true
+			}
+		case *ssa.UnOp:
+			if r.Op == token.MUL && sendsOn(fn, r) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isUnbufferedChan(ch *ssa.MakeChan) bool {
+	size, ok := GetConstantInt64(ch.Size)
+	return ok && size == 0
+}
+
+// loopBlocks returns the blocks of fn that belong to a loop.
+func loopBlocks(fn *ssa.Function) map[*ssa.BasicBlock]bool {
+	blocks := make(map[*ssa.BasicBlock]bool)
+	for _, region := range findLoopRegions(fn) {
+		for _, block := range region.blocks {
+			blocks[block] = true
+		}
+	}
+	return blocks
+}
@@ -0,0 +1,70 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/securego/gosec/v2/taint"
+)
+
+// HeaderInjection returns a configuration for detecting HTTP response header
+// injection (CRLF injection / response splitting) vulnerabilities.
+func HeaderInjection() taint.Config {
+	return taint.Config{
+		Sources: taint.DefaultSources,
+		Sinks: taint.WithLabels(taint.LabelUserInput|taint.LabelURL|taint.LabelQuery, []taint.Sink{
+			// For http.Header methods, Args[0] is receiver.
+			// Check both the header name and the header value.
+			{Package: "net/http", Receiver: "Header", Method: "Set", CheckArgs: []int{1, 2}},
+			{Package: "net/http", Receiver: "Header", Method: "Add", CheckArgs: []int{1, 2}},
+
+			// http.SetCookie(w, cookie) - only Name and Value end up verbatim
+			// in the Set-Cookie header line.
+			{Package: "net/http", Method: "SetCookie", CheckArgs: []int{1}, ArgFields: map[int][]string{1: {"Name", "Value"}}},
+
+			// Direct map assignment: w.Header()["X-Key"] = []string{v}
+			{Package: "net/http", Receiver: "Header", MapStore: true},
+		}),
+		Sanitizers: []taint.Sanitizer{
+			// textproto.TrimString removes leading/trailing ASCII whitespace
+			// including CR and LF.
+			{Package: "net/textproto", Method: "TrimString"},
+
+			// URL escaping encodes CR and LF.
+			{Package: "net/url", Method: "QueryEscape"},
+			{Package: "net/url", Method: "PathEscape"},
+
+			// Numeric conversions produce CRLF-free output.
+			{Package: "strconv", Method: "Atoi"},
+			{Package: "strconv", Method: "ParseInt"},
+			{Package: "strconv", Method: "ParseUint"},
+
+			// Replacing every CR and every LF with a string free of them.
+			{Package: "strings", Method: "ReplaceAll", Strips: "\r\n"},
+			{Package: "strings", Method: "Replace", Strips: "\r\n"},
+		},
+	}
+}
+
+// newHeaderInjectionAnalyzer creates an analyzer for detecting HTTP response header
+// injection vulnerabilities via taint analysis (G711)
+func newHeaderInjectionAnalyzer(id string, description string) *analysis.Analyzer {
+	config := HeaderInjection()
+	rule := HeaderInjectionRule
+	rule.ID = id
+	rule.Description = description
+	return taint.NewGosecAnalyzer(&rule, &config)
+}
@@ -23,20 +23,8 @@ import (
 // LogInjection returns a configuration for detecting log injection vulnerabilities.
 func LogInjection() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
-			// Type sources: tainted when received as parameters
-			{Package: "net/http", Name: "Request", Pointer: true},
-			{Package: "net/url", Name: "URL", Pointer: true},
-
-			// Function sources
-			{Package: "os", Name: "Args", IsFunc: true},
-			{Package: "os", Name: "Getenv", IsFunc: true},
-
-			// I/O sources
-			{Package: "bufio", Name: "Reader", Pointer: true},
-			{Package: "bufio", Name: "Scanner", Pointer: true},
-		},
-		Sinks: []taint.Sink{
+		Sources: taint.DefaultSources,
+		Sinks: taint.WithLabels(taint.LabelUserInput|taint.LabelURL, []taint.Sink{
 			{Package: "log", Method: "Print"},
 			{Package: "log", Method: "Printf"},
 			{Package: "log", Method: "Println"},
@@ -59,7 +47,7 @@ func LogInjection() taint.Config {
 			{Package: "log/slog", Method: "Warn", CheckArgs: []int{0}},
 			{Package: "log/slog", Method: "Error", CheckArgs: []int{0}},
 			{Package: "log/slog", Method: "Debug", CheckArgs: []int{0}},
-		},
+		}),
 		Sanitizers: []taint.Sanitizer{
 			// strings.ReplaceAll can strip newlines/CRLF for log injection
 			{Package: "strings", Method: "ReplaceAll"},
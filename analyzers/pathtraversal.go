@@ -23,25 +23,9 @@ import (
 // PathTraversal returns a configuration for detecting path traversal vulnerabilities.
 func PathTraversal() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
-			// Type sources: tainted when received as function parameters
-			{Package: "net/http", Name: "Request", Pointer: true},
-			{Package: "net/url", Name: "URL", Pointer: true},
-			{Package: "bufio", Name: "Reader", Pointer: true},
-			{Package: "bufio", Name: "Scanner", Pointer: true},
-
-			// Function sources: always produce tainted data
-			{Package: "os", Name: "Args", IsFunc: true},
-			{Package: "os", Name: "Getenv", IsFunc: true},
-			{Package: "os", Name: "ReadFile", IsFunc: true},
-
-			// NOTE: os.File is NOT a source type. Reading from a locally-opened file
-			// with a hardcoded path is not tainted. The file path argument to os.Open
-			// is what the sink checks. If someone opens a file from user input and
-			// then reads from it, the taint flows through the path argument, not the
-			// File type itself.
-		},
-		Sinks: []taint.Sink{
+		Sources: taint.DefaultSources,
+		// File contents are trusted by most rules, but not when they name other files.
+		Sinks: taint.WithLabels(taint.LabelUserInput|taint.LabelFileContent|taint.LabelURL, []taint.Sink{
 			{Package: "os", Method: "Open"},
 			{Package: "os", Method: "OpenFile"},
 			{Package: "os", Method: "Create"},
@@ -64,7 +48,11 @@ func PathTraversal() taint.Config {
 			// HTTP file-serving functions: user-controlled path = arbitrary file read
 			{Package: "net/http", Method: "ServeFile", CheckArgs: []int{2}},
 			{Package: "net/http", Method: "ServeFileFS", CheckArgs: []int{3}},
-		},
+
+			// NOTE: (*os.Root).Open/OpenFile/Create/... and os.OpenInRoot are
+			// deliberately not sinks. They resolve names beneath a fixed root and
+			// reject traversal outside of it, so they are the safe replacement.
+		}),
 		Sanitizers: []taint.Sanitizer{
 			// filepath.Clean normalizes and removes traversal components
 			{Package: "path/filepath", Method: "Clean"},
@@ -90,7 +78,14 @@ func PathTraversal() taint.Config {
 			{Package: "strconv", Method: "ParseUint"},
 			{Package: "strconv", Method: "ParseFloat"},
 			{Package: "strconv", Method: "ParseBool"},
+
+			// Guards: the checked value is safe inside the branch where they
+			// return true, e.g. if !filepath.IsLocal(p) { return }.
+			{Package: "path/filepath", Method: "IsLocal", Guard: true},
+			{Package: "io/fs", Method: "ValidPath", Guard: true},
 		},
+		// Files looked up in an allowlist, e.g. if !allowed[name] { return }.
+		AllowlistMaps: true,
 	}
 }
 
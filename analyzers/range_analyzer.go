@@ -65,6 +65,7 @@ type RangeAnalyzer struct {
 	ByteRangeCache map[ssa.Value]ByteRange
 	BufferLenCache map[ssa.Value]int64
 	reachStack     []*ssa.BasicBlock
+	depthExceeded  func(depth int) bool // depth budget of the owning state
 }
 
 var rangeAnalyzerPool = sync.Pool{
@@ -102,17 +103,27 @@ func (res *rangeResult) CopyFrom(other *rangeResult) {
 	res.isRangeCheck = other.isRangeCheck
 }
 
-// NewRangeAnalyzer acquires a RangeAnalyzer from the pool.
-func NewRangeAnalyzer() *RangeAnalyzer {
-	return rangeAnalyzerPool.Get().(*RangeAnalyzer)
+// NewRangeAnalyzer acquires a RangeAnalyzer from the pool. depthExceeded is
+// the depth budget of the analysis owning it, such as
+// BaseAnalyzerState.DepthExceeded.
+func NewRangeAnalyzer(depthExceeded func(depth int) bool) *RangeAnalyzer {
+	ra := rangeAnalyzerPool.Get().(*RangeAnalyzer)
+	ra.depthExceeded = depthExceeded
+	return ra
 }
 
 // Release returns the RangeAnalyzer to the pool after clearing its caches.
 func (ra *RangeAnalyzer) Release() {
 	ra.ResetCache()
+	ra.depthExceeded = nil
 	rangeAnalyzerPool.Put(ra)
 }
 
+// beyondDepth reports whether the recursion is deeper than the depth budget.
+func (ra *RangeAnalyzer) beyondDepth() bool {
+	return ra.depthExceeded(ra.Depth)
+}
+
 func (ra *RangeAnalyzer) ResetCache() {
 	for _, res := range ra.RangeCache {
 		res.shared = false
@@ -176,7 +187,7 @@ func (ra *RangeAnalyzer) ResolveRange(v ssa.Value, block *ssa.BasicBlock) *range
 		ra.releaseResult(res)
 	}
 
-	if ra.Depth > MaxDepth {
+	if ra.beyondDepth() {
 		result.shared = true
 		ra.RangeCache[key] = result
 		return result
@@ -1101,7 +1112,7 @@ func (ra *RangeAnalyzer) ResolveByteRange(val ssa.Value) (ByteRange, bool) {
 		return r, true
 	}
 
-	if ra.Depth > MaxDepth {
+	if ra.beyondDepth() {
 		return ByteRange{}, false
 	}
 	ra.Depth++
@@ -1472,6 +1483,11 @@ func (ra *RangeAnalyzer) resolveAllocRange(alloc *ssa.Alloc, block *ssa.BasicBlo
 	if refs == nil {
 		return res // No refs, unknown
 	}
+	// The address escapes (e.g. binary.Read(r, order, &n)): the stores do not
+	// cover every write, so the range is unknown.
+	if allocAddressEscapes(alloc) {
+		return res
+	}
 
 	for _, ref := range *refs {
 		if store, ok := ref.(*ssa.Store); ok && store.Addr == alloc {
@@ -1522,3 +1538,24 @@ func (ra *RangeAnalyzer) resolveAllocRange(alloc *ssa.Alloc, block *ssa.BasicBlo
 
 	return res
 }
+
+// allocAddressEscapes reports whether the address of alloc is used for
+// anything but direct loads and stores.
+func allocAddressEscapes(alloc *ssa.Alloc) bool {
+	for _, ref := range *alloc.Referrers() {
+		switch r := ref.(type) {
+		case *ssa.Store:
+			if r.Addr != alloc {
+				return true
+			}
+		case *ssa.UnOp:
+			if r.Op != token.MUL {
+				return true
+			}
+		case *ssa.DebugRef:
+		default:
+			return true
+		}
+	}
+	return false
+}
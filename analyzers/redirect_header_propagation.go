@@ -54,6 +54,9 @@ func runRedirectHeaderPropagationAnalysis(pass *analysis.Pass) (any, error) {
 		return nil, err
 	}
 
+	state := NewBaseState(pass)
+	defer state.Release()
+
 	issuesByPos := make(map[token.Pos]*issue.Issue)
 	for _, fn := range collectAnalyzerFunctions(ssaResult.SSA.SrcFuncs) {
 		reqParam, hasVia := findRedirectLikeParams(fn)
@@ -65,7 +68,7 @@ func runRedirectHeaderPropagationAnalysis(pass *analysis.Pass) (any, error) {
 			for _, instr := range block.Instrs {
 				switch v := instr.(type) {
 				case *ssa.Store:
-					if isRequestHeaderStore(v, reqParam) {
+					if isRequestHeaderStore(state, v, reqParam) {
 						addRedirectIssue(issuesByPos, pass, v.Pos(), msgUnsafeRedirectHeaderCopy, issue.High, issue.High)
 					}
 				case *ssa.Call:
@@ -75,7 +78,7 @@ func runRedirectHeaderPropagationAnalysis(pass *analysis.Pass) (any, error) {
 					if len(v.Call.Args) < 2 {
 						continue
 					}
-					if !isRequestHeaderValue(v.Call.Args[0], reqParam) {
+					if !isRequestHeaderValue(state, v.Call.Args[0], reqParam) {
 						continue
 					}
 					headerName := extractStringConst(v.Call.Args[1])
@@ -192,7 +195,7 @@ func isRequestSliceType(t types.Type) bool {
 	return isHTTPRequestPointerType(slice.Elem())
 }
 
-func isRequestHeaderStore(store *ssa.Store, reqParam *ssa.Parameter) bool {
+func isRequestHeaderStore(s *BaseAnalyzerState, store *ssa.Store, reqParam *ssa.Parameter) bool {
 	fieldAddr, ok := store.Addr.(*ssa.FieldAddr)
 	if !ok {
 		return false
@@ -204,14 +207,14 @@ func isRequestHeaderStore(store *ssa.Store, reqParam *ssa.Parameter) bool {
 	if !isHTTPHeaderType(fieldType) {
 		return false
 	}
-	return valueDependsOn(fieldAddr.X, reqParam, 0)
+	return s.valueDependsOn(fieldAddr.X, reqParam, 0)
 }
 
-func isRequestHeaderValue(val ssa.Value, reqParam *ssa.Parameter) bool {
+func isRequestHeaderValue(s *BaseAnalyzerState, val ssa.Value, reqParam *ssa.Parameter) bool {
 	if val == nil {
 		return false
 	}
-	if isHTTPHeaderType(val.Type()) && valueDependsOn(val, reqParam, 0) {
+	if isHTTPHeaderType(val.Type()) && s.valueDependsOn(val, reqParam, 0) {
 		return true
 	}
 	return false
@@ -260,7 +263,9 @@ func extractStringConst(v ssa.Value) string {
 	return constant.StringVal(c.Value)
 }
 
-func valueDependsOn(value ssa.Value, target ssa.Value, depth int) bool {
-	checker := newDependencyChecker()
+// valueDependsOn reports whether value is derived from target, within the
+// depth budget of the state.
+func (s *BaseAnalyzerState) valueDependsOn(value ssa.Value, target ssa.Value, depth int) bool {
+	checker := newDependencyChecker(s.DepthExceeded)
 	return checker.dependsOnDepth(value, target, depth)
 }
@@ -283,10 +283,10 @@ func runSliceBounds(pass *analysis.Pass) (result any, err error) {
 			}
 			var processBlock func(block *ssa.BasicBlock, depth int)
 			processBlock = func(block *ssa.BasicBlock, depth int) {
-				if depth == MaxDepth {
+				depth++
+				if state.DepthExceeded(depth) {
 					return
 				}
-				depth++
 				for _, instr := range block.Instrs {
 					if _, ok := issues[instr]; ok {
 						switch bound {
@@ -503,10 +503,10 @@ func decomposeIndex(v ssa.Value) (ssa.Value, int) {
 
 // trackSliceBounds recursively follows slice referrers to check for index and boundary violations.
 func (s *sliceBoundsState) trackSliceBounds(depth int, sliceCap int, slice ssa.Node, violations *[]ssa.Instruction, ifs map[ssa.If]*ssa.BinOp) {
-	if depth == MaxDepth {
+	depth++
+	if s.DepthExceeded(depth) {
 		return
 	}
-	depth++
 
 	key := trackCacheKey{slice, sliceCap}
 	if res, ok := s.trackCache[key]; ok {
@@ -547,7 +547,7 @@ func (s *sliceBoundsState) trackSliceBounds(depth int, sliceCap int, slice ssa.N
 					*localViolations = append(*localViolations, refinstr)
 				}
 			case *ssa.Call:
-				if ifref, cond := extractSliceIfLenCondition(refinstr); ifref != nil && cond != nil {
+				if ifref, cond := s.extractSliceIfLenCondition(refinstr); ifref != nil && cond != nil {
 					localIfs[*ifref] = cond
 				} else {
 					parPos := -1
@@ -713,7 +713,7 @@ func (s *sliceBoundsState) extractIntValueIndexAddr(refinstr *ssa.IndexAddr, sli
 	depth := 0
 
 	head := 0
-	for head < len(s.valQueue) && depth < MaxDepth {
+	for head < len(s.valQueue) && depth < s.DepthLimit() {
 		levelSize := len(s.valQueue) - head
 		for i := 0; i < levelSize; i++ {
 			item := s.valQueue[head]
@@ -768,10 +768,10 @@ func (s *sliceBoundsState) extractIntValueIndexAddr(refinstr *ssa.IndexAddr, sli
 
 // checkAllSlicesBounds validates slice operation boundaries against the known capacity or limit.
 func (s *sliceBoundsState) checkAllSlicesBounds(depth int, sliceCap int, slice *ssa.Slice, violations *[]ssa.Instruction, ifs map[ssa.If]*ssa.BinOp) {
-	if depth == MaxDepth {
+	depth++
+	if s.DepthExceeded(depth) {
 		return
 	}
-	depth++
 	if violations == nil {
 		violations = &[]ssa.Instruction{}
 	}
@@ -810,7 +810,7 @@ func (s *sliceBoundsState) checkAllSlicesBounds(depth int, sliceCap int, slice *
 	}
 }
 
-func extractSliceIfLenCondition(call *ssa.Call) (*ssa.If, *ssa.BinOp) {
+func (s *sliceBoundsState) extractSliceIfLenCondition(call *ssa.Call) (*ssa.If, *ssa.BinOp) {
 	if builtInLen, ok := call.Call.Value.(*ssa.Builtin); ok {
 		if builtInLen.Name() == "len" {
 			refs := []ssa.Instruction{}
@@ -818,7 +818,7 @@ func extractSliceIfLenCondition(call *ssa.Call) (*ssa.If, *ssa.BinOp) {
 				refs = append(refs, *call.Referrers()...)
 			}
 			depth := 0
-			for len(refs) > 0 && depth < MaxDepth {
+			for len(refs) > 0 && !s.DepthExceeded(depth) {
 				newrefs := []ssa.Instruction{}
 				for _, ref := range refs {
 					if binop, ok := ref.(*ssa.BinOp); ok {
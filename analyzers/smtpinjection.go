@@ -23,19 +23,8 @@ import (
 // SMTPInjection returns a configuration for detecting SMTP command/header injection vulnerabilities.
 func SMTPInjection() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
-			// Type sources: tainted when received as parameters
-			{Package: "net/http", Name: "Request", Pointer: true},
-			{Package: "net/url", Name: "URL", Pointer: true},
-			{Package: "net/url", Name: "Values"},
-			{Package: "bufio", Name: "Reader", Pointer: true},
-			{Package: "bufio", Name: "Scanner", Pointer: true},
-
-			// Function sources
-			{Package: "os", Name: "Args", IsFunc: true},
-			{Package: "os", Name: "Getenv", IsFunc: true},
-		},
-		Sinks: []taint.Sink{
+		Sources: taint.DefaultSources,
+		Sinks: taint.WithLabels(taint.LabelUserInput|taint.LabelURL|taint.LabelQuery, []taint.Sink{
 			// net/smtp.SendMail(addr, auth, from, to, msg)
 			// Check sender and recipient envelope fields.
 			{Package: "net/smtp", Method: "SendMail", CheckArgs: []int{2, 3}},
@@ -43,7 +32,7 @@ func SMTPInjection() taint.Config {
 			// For smtp.Client methods, Args[0] is receiver.
 			{Package: "net/smtp", Receiver: "Client", Method: "Mail", Pointer: true, CheckArgs: []int{1}},
 			{Package: "net/smtp", Receiver: "Client", Method: "Rcpt", Pointer: true, CheckArgs: []int{1}},
-		},
+		}),
 		Sanitizers: []taint.Sanitizer{
 			// net/mail parsers enforce RFC-compatible mailbox/address syntax.
 			{Package: "net/mail", Method: "ParseAddress"},
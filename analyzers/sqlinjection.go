@@ -23,19 +23,8 @@ import (
 // SQLInjection returns a configuration for detecting SQL injection vulnerabilities.
 func SQLInjection() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
-			// Type sources: tainted when received as parameters
-			{Package: "net/http", Name: "Request", Pointer: true},
-			{Package: "net/url", Name: "URL", Pointer: true},
-			{Package: "net/url", Name: "Values"},
-			{Package: "bufio", Name: "Reader", Pointer: true},
-			{Package: "bufio", Name: "Scanner", Pointer: true},
-
-			// Function sources
-			{Package: "os", Name: "Args", IsFunc: true},
-			{Package: "os", Name: "Getenv", IsFunc: true},
-		},
-		Sinks: []taint.Sink{
+		Sources: taint.DefaultSources,
+		Sinks: taint.WithLabels(taint.LabelUserInput|taint.LabelURL|taint.LabelQuery, []taint.Sink{
 			// For SQL methods, Args[0] is receiver, Args[1] is query string
 			// Only check query string argument; prepared statement params are safe
 			{Package: "database/sql", Receiver: "DB", Method: "Query", Pointer: true, CheckArgs: []int{1}},
@@ -54,7 +43,7 @@ func SQLInjection() taint.Config {
 			{Package: "database/sql", Receiver: "Tx", Method: "ExecContext", Pointer: true, CheckArgs: []int{2}},
 			{Package: "database/sql", Receiver: "Tx", Method: "Prepare", Pointer: true, CheckArgs: []int{1}},
 			{Package: "database/sql", Receiver: "Tx", Method: "PrepareContext", Pointer: true, CheckArgs: []int{2}},
-		},
+		}),
 		Sanitizers: []taint.Sanitizer{
 			// No stdlib sanitizers for SQL — use parameterized queries instead.
 			// The CheckArgs configuration already excludes prepared statement params.
@@ -23,25 +23,8 @@ import (
 // SSRF returns a configuration for detecting Server-Side Request Forgery vulnerabilities.
 func SSRF() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
-			// Type sources: tainted when received as function parameters from external callers
-			{Package: "net/http", Name: "Request", Pointer: true},
-
-			// Function sources: always produce tainted data
-			{Package: "os", Name: "Args", IsFunc: true},
-			{Package: "os", Name: "Getenv", IsFunc: true},
-
-			// I/O sources that read from external input
-			{Package: "bufio", Name: "Reader", Pointer: true},
-			{Package: "bufio", Name: "Scanner", Pointer: true},
-
-			// NOTE: *os.File is NOT a source type here. A file opened with a
-			// hardcoded path (e.g., config file) is not an external input source.
-			// If the file was opened from user-controlled input, the taint would
-			// flow through the path argument, and that's a path traversal issue (G703),
-			// not SSRF.
-		},
-		Sinks: []taint.Sink{
+		Sources: taint.DefaultSources,
+		Sinks: taint.WithLabels(taint.LabelUserInput, []taint.Sink{
 			// URL argument is what we check - these are the first data arg
 			{Package: "net/http", Method: "Get", CheckArgs: []int{0}},
 			{Package: "net/http", Method: "Post", CheckArgs: []int{0}},
@@ -60,13 +43,15 @@ func SSRF() taint.Config {
 			{Package: "net", Method: "DialTimeout", CheckArgs: []int{1}},
 			{Package: "net", Method: "LookupHost", CheckArgs: []int{0}},
 			{Package: "net/http/httputil", Method: "NewSingleHostReverseProxy", CheckArgs: []int{0}},
-		},
+		}),
 		Sanitizers: []taint.Sanitizer{
 			// URL validation/parsing that enforces allowlists would be custom;
 			// there are no stdlib sanitizers that truly prevent SSRF.
 			// However, url.Parse itself is not a sanitizer — it doesn't restrict
 			// which hosts can be accessed.
 		},
+		// Hosts looked up in an allowlist, e.g. if !allowedHosts[host] { return }.
+		AllowlistMaps: true,
 	}
 }
 
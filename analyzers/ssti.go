@@ -32,20 +32,8 @@ import (
 // text/template into an HTTP response produces unescaped HTML, enabling XSS.
 func SSTI() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
-			// Type sources: tainted when received as parameters
-			{Package: "net/http", Name: "Request", Pointer: true},
-			{Package: "net/url", Name: "Values"},
-
-			// Function sources
-			{Package: "os", Name: "Args", IsFunc: true},
-			{Package: "os", Name: "Getenv", IsFunc: true},
-
-			// I/O sources
-			{Package: "bufio", Name: "Reader", Pointer: true},
-			{Package: "bufio", Name: "Scanner", Pointer: true},
-		},
-		Sinks: []taint.Sink{
+		Sources: taint.DefaultSources,
+		Sinks: taint.WithLabels(taint.LabelUserInput|taint.LabelQuery, []taint.Sink{
 			// CRITICAL: user input flows into the template string itself.
 			// Template.Parse takes a single string argument (the template text).
 			{Package: "text/template", Receiver: "Template", Method: "Parse", Pointer: true, CheckArgs: []int{1}},
@@ -71,7 +59,7 @@ func SSTI() taint.Config {
 				CheckArgs:     []int{3},
 				ArgTypeGuards: map[int]string{1: "net/http.ResponseWriter"},
 			},
-		},
+		}),
 		Sanitizers: []taint.Sanitizer{
 			// HTML escaping neutralizes both SSTI template directives and XSS payloads
 			{Package: "html", Method: "EscapeString"},
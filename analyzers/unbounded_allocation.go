@@ -0,0 +1,72 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/securego/gosec/v2/taint"
+)
+
+// maxBoundedAllocation is the largest upper bound of a size that is considered
+// checked. Bounds implied by the type alone, such as the 4 GiB of a uint32
+// length prefix, are above it.
+const maxBoundedAllocation = 1 << 30
+
+// UnboundedAllocation returns a configuration for detecting allocations whose
+// size is controlled by untrusted input.
+func UnboundedAllocation() taint.Config {
+	return taint.Config{
+		Sources: append(slices.Clone(taint.DefaultSources),
+			// Length prefixes decoded from a byte stream: binary.Read(r, order, &n)
+			taint.Source{Package: "encoding/binary", Name: "Read", IsFunc: true, Scan: true, Label: taint.LabelNetwork},
+		),
+		Sinks: taint.WithLabels(taint.LabelUserInput, []taint.Sink{
+			// make([]T, n), make([]T, 0, n), make(map[K]V, n), make(chan T, n)
+			{Make: true},
+
+			// Repeat allocates count times the size of its input.
+			{Package: "bytes", Method: "Repeat", CheckArgs: []int{1}},
+			{Package: "strings", Method: "Repeat", CheckArgs: []int{1}},
+
+			// io.CopyN reads n bytes, usually into an in-memory buffer.
+			{Package: "io", Method: "CopyN", CheckArgs: []int{2}},
+		}),
+		Bounded: isAllocationBounded,
+	}
+}
+
+// isAllocationBounded reports whether an upper-bound check dominating block
+// limits the size v, resolving its range within the depth budget of the
+// taint analysis.
+func isAllocationBounded(v ssa.Value, block *ssa.BasicBlock, depthExceeded func(depth int) bool) bool {
+	ra := NewRangeAnalyzer(depthExceeded)
+	defer ra.Release()
+	res := ra.ResolveRange(v, block)
+	return res.isRangeCheck && res.maxValueSet && res.maxValue <= maxBoundedAllocation
+}
+
+// newUnboundedAllocationAnalyzer creates an analyzer for detecting allocations
+// sized by untrusted input via taint analysis (G712)
+func newUnboundedAllocationAnalyzer(id string, description string) *analysis.Analyzer {
+	config := UnboundedAllocation()
+	rule := UnboundedAllocationRule
+	rule.ID = id
+	rule.Description = description
+	return taint.NewGosecAnalyzer(&rule, &config)
+}
@@ -0,0 +1,376 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/securego/gosec/v2/internal/ssautil"
+	"github.com/securego/gosec/v2/issue"
+)
+
+// securityCriticalCalls lists the functions whose other results are nil or
+// zeroed, or whose effect did not happen, when they return an error. Keys are
+// import paths, or import paths followed by a type name for methods.
+var securityCriticalCalls = map[string][]string{
+	"crypto/rand": {"Read", "Int", "Prime"},
+	"crypto/x509": {
+		"ParseCertificate", "ParseCertificates", "ParseCertificateRequest", "ParseRevocationList",
+		"ParsePKCS1PrivateKey", "ParsePKCS1PublicKey", "ParsePKCS8PrivateKey", "ParsePKIXPublicKey",
+		"ParseECPrivateKey", "CreateCertificate", "SystemCertPool",
+	},
+	"crypto/x509.Certificate": {"Verify", "VerifyHostname", "CheckSignatureFrom", "CheckSignature"},
+	"crypto/tls":              {"LoadX509KeyPair", "X509KeyPair"},
+	"crypto/rsa": {
+		"GenerateKey", "GenerateMultiPrimeKey", "EncryptOAEP", "DecryptOAEP", "EncryptPKCS1v15",
+		"DecryptPKCS1v15", "SignPKCS1v15", "SignPSS", "VerifyPKCS1v15", "VerifyPSS",
+	},
+	"crypto/ecdsa":               {"GenerateKey", "SignASN1", "Sign"},
+	"crypto/ed25519":             {"GenerateKey"},
+	"crypto/cipher.AEAD":         {"Open"},
+	"golang.org/x/crypto/bcrypt": {"GenerateFromPassword", "CompareHashAndPassword"},
+	"golang.org/x/crypto/ssh":    {"ParsePrivateKey", "ParseAuthorizedKey", "ParsePublicKey", "NewSignerFromKey"},
+}
+
+type uncheckedSecurityErrorState struct {
+	*BaseAnalyzerState
+	calls  map[string]map[string]bool
+	issues map[token.Pos]*issue.Issue
+}
+
+// newSecurityCriticalCalls returns the default calls extended with the calls
+// configured for the rule, in the format of the G104 whitelist. Names prefixed
+// with "!" remove a default call:
+//
+//	{"G128": {"crypto/x509": ["ParseCRL"], "golang.org/x/crypto/bcrypt": ["!CompareHashAndPassword"]}}
+func newSecurityCriticalCalls(conf any) map[string]map[string]bool {
+	calls := make(map[string]map[string]bool)
+	add := func(selector string, names ...string) {
+		if calls[selector] == nil {
+			calls[selector] = make(map[string]bool)
+		}
+		for _, name := range names {
+			calls[selector][name] = true
+		}
+	}
+	for selector, names := range securityCriticalCalls {
+		add(selector, names...)
+	}
+	configured, ok := conf.(map[string]any)
+	if !ok {
+		return calls
+	}
+	var removed [][2]string
+	for selector, names := range configured {
+		if names, ok := names.([]any); ok {
+			for _, name := range names {
+				name, ok := name.(string)
+				if !ok {
+					continue
+				}
+				if trimmed, found := strings.CutPrefix(name, "!"); found {
+					removed = append(removed, [2]string{selector, trimmed})
+				} else {
+					add(selector, name)
+				}
+			}
+		}
+	}
+	// Removals win over additions, whatever their order in the configuration
+	for _, entry := range removed {
+		delete(calls[entry[0]], entry[1])
+	}
+	return calls
+}
+
+func newUncheckedSecurityErrorAnalyzer(id string, description string) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:     id,
+		Doc:      description,
+		Run:      runUncheckedSecurityError,
+		Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	}
+}
+
+func runUncheckedSecurityError(pass *analysis.Pass) (any, error) {
+	ssaResult, err := ssautil.GetSSAResult(pass)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &uncheckedSecurityErrorState{
+		BaseAnalyzerState: NewBaseState(pass),
+		calls:             newSecurityCriticalCalls(ssaResult.Config[pass.Analyzer.Name]),
+		issues:            make(map[token.Pos]*issue.Issue),
+	}
+	defer state.Release()
+
+	for _, fn := range collectAnalyzerFunctions(ssaResult.SSA.SrcFuncs) {
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				if call, ok := instr.(*ssa.Call); ok {
+					state.checkCall(call)
+				}
+			}
+		}
+	}
+
+	if len(state.issues) == 0 {
+		return nil, nil
+	}
+	issues := make([]*issue.Issue, 0, len(state.issues))
+	for _, i := range state.issues {
+		issues = append(issues, i)
+	}
+	return issues, nil
+}
+
+func (s *uncheckedSecurityErrorState) checkCall(call *ssa.Call) {
+	name, ok := s.criticalCallee(call.Common())
+	if !ok {
+		return
+	}
+	errVal, results, ok := callResults(call)
+	if !ok {
+		return
+	}
+
+	checks := usedBy(errVal)
+	if len(checks) == 0 {
+		s.addIssue(call.Pos(), fmt.Sprintf("Error returned by %s is ignored", name), issue.High)
+		return
+	}
+
+	// Blocks branching on a comparison of the error with nil, and the
+	// successors of those blocks taken when the error is nil
+	var checkBlocks, nilBlocks []*ssa.BasicBlock
+	var others []ssa.Instruction
+	for _, ref := range checks {
+		cmp, ok := ref.(*ssa.BinOp)
+		if !ok || (cmp.Op != token.EQL && cmp.Op != token.NEQ) || (!isNilValue(cmp.X) && !isNilValue(cmp.Y)) {
+			others = append(others, ref)
+			continue
+		}
+		for _, cmpRef := range safeReferrers(cmp) {
+			if ifInstr, ok := cmpRef.(*ssa.If); ok {
+				block := ifInstr.Block()
+				checkBlocks = append(checkBlocks, block)
+				succ, errSucc := block.Succs[1], block.Succs[0]
+				if cmp.Op == token.EQL {
+					succ, errSucc = errSucc, succ
+				}
+				// A successor also reached from the error branch, such as
+				// the block after "if err != nil { log(err) }", is not safe
+				// unless the error branch never completes
+				if joinedOnlyFrom(succ, block, errSucc) {
+					nilBlocks = append(nilBlocks, succ)
+				}
+			}
+		}
+	}
+	for _, use := range others {
+		if !checkedBefore(use, checkBlocks) {
+			// Handled elsewhere: returned, wrapped or passed to a function
+			return
+		}
+	}
+
+	for _, result := range results {
+		for _, use := range usedBy(result) {
+			if !checkedNil(use, nilBlocks) {
+				s.addIssue(call.Pos(), fmt.Sprintf("Result of %s is used before its error is checked", name), issue.Medium)
+				return
+			}
+		}
+	}
+}
+
+func (s *uncheckedSecurityErrorState) addIssue(pos token.Pos, msg string, confidence issue.Score) {
+	if _, found := s.issues[pos]; found {
+		return
+	}
+	s.issues[pos] = newIssue(s.Pass.Analyzer.Name, msg, s.Pass.Fset, pos, issue.High, confidence)
+}
+
+// criticalCallee returns the name of the function called when it is one of
+// the security-critical calls.
+func (s *uncheckedSecurityErrorState) criticalCallee(common *ssa.CallCommon) (string, bool) {
+	var fn *types.Func
+	if common.IsInvoke() {
+		fn = common.Method
+	} else if callee := common.StaticCallee(); callee != nil {
+		if origin := callee.Origin(); origin != nil {
+			callee = origin
+		}
+		fn, _ = callee.Object().(*types.Func)
+	}
+	if fn == nil || fn.Pkg() == nil {
+		return "", false
+	}
+
+	selector, name := fn.Pkg().Path(), fn.Pkg().Name()+"."+fn.Name()
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		recvType := recv.Type()
+		if ptr, ok := recvType.(*types.Pointer); ok {
+			recvType = ptr.Elem()
+		}
+		named, ok := recvType.(*types.Named)
+		if !ok {
+			// Interface method: the receiver of the call names the interface
+			if !common.IsInvoke() {
+				return "", false
+			}
+			if named, ok = common.Value.Type().(*types.Named); !ok {
+				return "", false
+			}
+		}
+		selector += "." + named.Obj().Name()
+		name = fmt.Sprintf("%s.%s.%s", fn.Pkg().Name(), named.Obj().Name(), fn.Name())
+	}
+	return name, s.calls[selector][fn.Name()]
+}
+
+// callResults returns the error result of call and its other results that
+// are extracted. It fails when the call does not return an error last.
+func callResults(call *ssa.Call) (ssa.Value, []ssa.Value, bool) {
+	sig := call.Common().Signature()
+	n := sig.Results().Len()
+	if n == 0 || !isErrorType(sig.Results().At(n-1).Type()) {
+		return nil, nil, false
+	}
+	if n == 1 {
+		return call, nil, true
+	}
+
+	var errVal ssa.Value
+	var results []ssa.Value
+	for _, ref := range safeReferrers(call) {
+		extract, ok := ref.(*ssa.Extract)
+		if !ok {
+			continue
+		}
+		if extract.Index == n-1 {
+			errVal = extract
+		} else {
+			results = append(results, extract)
+		}
+	}
+	return errVal, results, true
+}
+
+// usedBy returns the instructions using v, ignoring debug information.
+func usedBy(v ssa.Value) []ssa.Instruction {
+	if v == nil {
+		return nil
+	}
+	var uses []ssa.Instruction
+	for _, ref := range safeReferrers(v) {
+		if _, ok := ref.(*ssa.DebugRef); !ok {
+			uses = append(uses, ref)
+		}
+	}
+	return uses
+}
+
+// checkedBefore reports whether use can only run after one of the checks,
+// i.e. in a block strictly dominated by the block branching on the error.
+func checkedBefore(use ssa.Instruction, checkBlocks []*ssa.BasicBlock) bool {
+	for _, block := range checkBlocks {
+		if block != use.Block() && block.Dominates(use.Block()) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkedNil reports whether use can only run once the error was found to be
+// nil, i.e. in a block dominated by the nil successor of a check.
+func checkedNil(use ssa.Instruction, nilBlocks []*ssa.BasicBlock) bool {
+	for _, block := range nilBlocks {
+		if block.Dominates(use.Block()) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinedOnlyFrom reports whether succ is only reached from the check block,
+// or from blocks of the error branch starting at errSucc that end the
+// program, the goroutine or the test before falling through.
+func joinedOnlyFrom(succ, check, errSucc *ssa.BasicBlock) bool {
+	for _, pred := range succ.Preds {
+		if pred == check {
+			continue
+		}
+		if pred == succ || !errSucc.Dominates(pred) || !terminatesBranch(pred, errSucc) {
+			return false
+		}
+	}
+	return true
+}
+
+// terminatesBranch reports whether a block on the dominator path from start
+// to block calls a function that does not return.
+func terminatesBranch(block, start *ssa.BasicBlock) bool {
+	for b := block; b != nil; b = b.Idom() {
+		for _, instr := range b.Instrs {
+			if call, ok := instr.(*ssa.Call); ok && isNoReturnCall(call.Common()) {
+				return true
+			}
+		}
+		if b == start {
+			break
+		}
+	}
+	return false
+}
+
+// isNoReturnCall reports whether the call never returns to its caller:
+// log.Fatal*, log.Panic*, os.Exit, runtime.Goexit and the testing methods
+// stopping the test, such as t.Fatal*.
+func isNoReturnCall(common *ssa.CallCommon) bool {
+	var fn *types.Func
+	if common.IsInvoke() {
+		fn = common.Method
+	} else if callee := common.StaticCallee(); callee != nil {
+		fn, _ = callee.Object().(*types.Func)
+	}
+	if fn == nil || fn.Pkg() == nil {
+		return false
+	}
+	name := fn.Name()
+	switch fn.Pkg().Path() {
+	case "log":
+		return strings.HasPrefix(name, "Fatal") || strings.HasPrefix(name, "Panic")
+	case "os":
+		return name == "Exit"
+	case "runtime":
+		return name == "Goexit"
+	case "testing":
+		return strings.HasPrefix(name, "Fatal") || strings.HasPrefix(name, "Skip") || name == "FailNow"
+	}
+	return false
+}
+
+func isErrorType(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
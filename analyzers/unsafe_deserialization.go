@@ -34,20 +34,8 @@ import (
 // DoS and external entity expansion.
 func UnsafeDeserialization() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
-			// Type sources: tainted when received as parameters
-			{Package: "net/http", Name: "Request", Pointer: true},
-			{Package: "net/url", Name: "Values"},
-
-			// Function sources
-			{Package: "os", Name: "Args", IsFunc: true},
-			{Package: "os", Name: "Getenv", IsFunc: true},
-
-			// I/O sources
-			{Package: "bufio", Name: "Reader", Pointer: true},
-			{Package: "bufio", Name: "Scanner", Pointer: true},
-		},
-		Sinks: []taint.Sink{
+		Sources: taint.DefaultSources,
+		Sinks: taint.WithLabels(taint.LabelUserInput|taint.LabelQuery, []taint.Sink{
 			// encoding/gob — highest risk: arbitrary type instantiation from wire format
 			// gob.NewDecoder takes an io.Reader (arg 0), so if the reader is tainted
 			// the decoder will process attacker-controlled data.
@@ -61,7 +49,7 @@ func UnsafeDeserialization() taint.Config {
 			// encoding/xml — deeply-nested structure DoS, entity expansion
 			{Package: "encoding/xml", Method: "NewDecoder", CheckArgs: []int{0}},
 			{Package: "encoding/xml", Method: "Unmarshal", CheckArgs: []int{0}},
-		},
+		}),
 		Sanitizers: []taint.Sanitizer{
 			// io.LimitReader bounds the amount of data read, mitigating DoS amplification
 			{Package: "io", Method: "LimitReader"},
@@ -0,0 +1,50 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyzers
+
+import (
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/securego/gosec/v2/taint"
+)
+
+// UnsafeReflection returns a configuration for detecting untrusted input that
+// selects the methods or fields reached through reflection, or the plugins
+// and symbols loaded at runtime. See CWE-470.
+func UnsafeReflection() taint.Config {
+	return taint.Config{
+		Sources: taint.DefaultSources,
+		Sinks: taint.WithLabels(taint.LabelUserInput, []taint.Sink{
+			// For reflect.Value methods, Args[0] is the receiver: only the
+			// name selects the method or field.
+			{Package: "reflect", Receiver: "Value", Method: "MethodByName", CheckArgs: []int{1}},
+			{Package: "reflect", Receiver: "Value", Method: "FieldByName", CheckArgs: []int{1}},
+
+			// plugin.Open(path) runs the init functions of the loaded plugin.
+			{Package: "plugin", Method: "Open", CheckArgs: []int{0}},
+			{Package: "plugin", Receiver: "Plugin", Method: "Lookup", Pointer: true, CheckArgs: []int{1}},
+		}),
+	}
+}
+
+// newUnsafeReflectionAnalyzer creates an analyzer for detecting unsafe
+// reflection and plugin loading via taint analysis (G713).
+func newUnsafeReflectionAnalyzer(id string, description string) *analysis.Analyzer {
+	config := UnsafeReflection()
+	rule := UnsafeReflectionRule
+	rule.ID = id
+	rule.Description = description
+	return taint.NewGosecAnalyzer(&rule, &config)
+}
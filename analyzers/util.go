@@ -15,13 +15,16 @@
 package analyzers
 
 import (
+	"bufio"
 	"fmt"
 	"go/constant"
 	"go/token"
 	"go/types"
 	"math"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 
 	"golang.org/x/tools/go/analysis"
@@ -32,6 +35,7 @@ import (
 )
 
 // MaxDepth defines the maximum recursion depth for SSA analysis to avoid infinite loops and memory exhaustion.
+// It is the default of the ssa-max-depth global option.
 const MaxDepth = 20
 
 const (
@@ -53,6 +57,8 @@ type BaseAnalyzerState struct {
 	BlockMap     map[*ssa.BasicBlock]bool
 	ClosureCache map[ssa.Value]bool
 	Depth        int
+	// Budget is the analysis budget of the package, nil when unbounded.
+	Budget *ssautil.Budget
 }
 
 // Error aliases for backward compatibility
@@ -86,14 +92,41 @@ var (
 
 // NewBaseState creates a new BaseAnalyzerState with pooled maps.
 func NewBaseState(pass *analysis.Pass) *BaseAnalyzerState {
-	return &BaseAnalyzerState{
+	s := &BaseAnalyzerState{
 		Pass:         pass,
-		Analyzer:     NewRangeAnalyzer(),
 		Visited:      visitedPool.Get().(map[ssa.Value]bool),
 		FuncMap:      funcMapPool.Get().(map[*ssa.Function]bool),
 		BlockMap:     blockMapPool.Get().(map[*ssa.BasicBlock]bool),
 		ClosureCache: closureCachePool.Get().(map[ssa.Value]bool),
 	}
+	if pass != nil {
+		if ssaResult, err := ssautil.GetSSAResult(pass); err == nil {
+			s.Budget = ssaResult.Budget
+		}
+	}
+	s.Analyzer = NewRangeAnalyzer(s.DepthExceeded)
+	return s
+}
+
+// DepthLimit returns the maximum recursion depth of the analysis, set by the
+// budget of the package or MaxDepth by default.
+func (s *BaseAnalyzerState) DepthLimit() int {
+	if s.Budget != nil && s.Budget.MaxDepth > 0 {
+		return s.Budget.MaxDepth
+	}
+	return MaxDepth
+}
+
+// DepthExceeded reports whether depth is beyond DepthLimit. When it is, the
+// analysis of the package is recorded as truncated.
+func (s *BaseAnalyzerState) DepthExceeded(depth int) bool {
+	if depth <= s.DepthLimit() {
+		return false
+	}
+	if s.Budget != nil && s.Pass.Analyzer != nil {
+		s.Budget.Truncate(s.Pass.Analyzer.Name, ssautil.TruncatedMaxDepth)
+	}
+	return true
 }
 
 // Reset clears the caches and maps for reuse within an analyzer run.
@@ -139,7 +172,7 @@ func (s *BaseAnalyzerState) Release() {
 // ResolveFuncs resolves a value to a list of possible functions (e.g., closures, phi nodes).
 // It reuses the state's ClosureCache to avoid cycles and redundant work.
 func (s *BaseAnalyzerState) ResolveFuncs(val ssa.Value, funcs *[]*ssa.Function) {
-	if val == nil || s.Depth > MaxDepth {
+	if val == nil || s.DepthExceeded(s.Depth) {
 		return
 	}
 	if s.ClosureCache[val] {
@@ -204,34 +237,57 @@ func newIssue(analyzerID string, desc string, fileSet *token.FileSet,
 	if file == nil {
 		return &issue.Issue{}
 	}
-	line := file.Line(pos)
-	col := file.Position(pos).Column
+	position := issuePosition(file, pos)
 
 	return &issue.Issue{
 		RuleID:     analyzerID,
-		File:       file.Name(),
-		Line:       strconv.Itoa(line),
-		Col:        strconv.Itoa(col),
+		File:       position.Filename,
+		Line:       strconv.Itoa(position.Line),
+		Col:        strconv.Itoa(position.Column),
 		Severity:   severity,
 		Confidence: confidence,
 		What:       desc,
 		Cwe:        issue.GetCweByRule(analyzerID),
-		Code:       issueCodeSnippet(fileSet, pos),
+		Code:       issueCodeSnippet(position),
 	}
 }
 
-func issueCodeSnippet(fileSet *token.FileSet, pos token.Pos) string {
-	file := fileSet.File(pos)
+// cgoOutputs caches whether a compiled file was generated by cgo, keyed by
+// file name.
+var cgoOutputs sync.Map
+
+// issuePosition returns the position of pos in the source file written by
+// the user. Files rewritten by cgo map back to the original file through
+// line directives; line directives of other files are not followed.
+func issuePosition(file *token.File, pos token.Pos) token.Position {
+	return file.PositionFor(pos, isCgoOutput(file.Name()))
+}
+
+// isCgoOutput reports whether the named file starts with the header written
+// by cmd/cgo.
+func isCgoOutput(name string) bool {
+	if cached, ok := cgoOutputs.Load(name); ok {
+		return cached.(bool)
+	}
+	generated := false
+	if f, err := os.Open(filepath.Clean(name)); err == nil {
+		line, _ := bufio.NewReader(f).ReadString('\n')
+		generated = strings.TrimSpace(line) == "// "+cgoGeneratedHeader
+		_ = f.Close()
+	}
+	cgoOutputs.Store(name, generated)
+	return generated
+}
 
-	start := (int64)(file.Line(pos))
+func issueCodeSnippet(position token.Position) string {
+	start := int64(position.Line)
 	if start-issue.SnippetOffset > 0 {
 		start = start - issue.SnippetOffset
 	}
-	end := (int64)(file.Line(pos))
-	end = end + issue.SnippetOffset
+	end := int64(position.Line) + issue.SnippetOffset
 
 	var code string
-	if file, err := os.Open(file.Name()); err == nil {
+	if file, err := os.Open(position.Filename); err == nil {
 		defer file.Close() // #nosec
 		code, err = issue.CodeSnippet(file, start, end)
 		if err != nil {
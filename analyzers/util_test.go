@@ -2,13 +2,19 @@ package analyzers
 
 import (
 	"go/constant"
+	"go/parser"
 	"go/token"
 	"go/types"
+	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/ssa"
+
+	"github.com/securego/gosec/v2/internal/ssautil"
 )
 
 var _ = Describe("GetConstantInt64", func() {
@@ -278,6 +284,23 @@ var _ = Describe("BaseAnalyzerState", func() {
 
 		Expect(funcs).To(BeEmpty())
 	})
+
+	It("should record depth truncations in the package budget", func() {
+		budget := &ssautil.Budget{MaxDepth: 3}
+		pass.Analyzer = &analysis.Analyzer{Name: "G999"}
+		pass.ResultOf = map[*analysis.Analyzer]any{
+			buildssa.Analyzer: &ssautil.SSAAnalyzerResult{Budget: budget},
+		}
+		state := NewBaseState(pass)
+
+		Expect(state.DepthLimit()).To(Equal(3))
+		Expect(state.DepthExceeded(3)).To(BeFalse())
+		Expect(budget.Truncations()).To(BeEmpty())
+		Expect(state.DepthExceeded(4)).To(BeTrue())
+		Expect(budget.Truncations()).To(Equal([]ssautil.Truncation{
+			{Analyzer: "G999", Reasons: []string{ssautil.TruncatedMaxDepth}},
+		}))
+	})
 })
 
 var _ = Describe("Slice utility functions", func() {
@@ -605,3 +628,26 @@ var _ = Describe("Utility helper functions", func() {
 		})
 	})
 })
+
+var _ = Describe("issuePosition", func() {
+	position := func(name, content string) token.Position {
+		path := filepath.Join(GinkgoT().TempDir(), name)
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		Expect(err).ShouldNot(HaveOccurred())
+		return issuePosition(fset.File(file.Pos()), file.Decls[0].Pos())
+	}
+
+	It("should follow line directives of files generated by cgo", func() {
+		pos := position("abc123-d", "// "+cgoGeneratedHeader+"\n\n//line /src/cgo.go:10:1\npackage main\n\nvar x = 1\n")
+		Expect(pos.Filename).To(Equal("/src/cgo.go"))
+		Expect(pos.Line).To(Equal(12))
+	})
+
+	It("should not follow line directives of other files", func() {
+		pos := position("parser.go", "//line /src/parser.y:10:1\npackage main\n\nvar x = 1\n")
+		Expect(filepath.Base(pos.Filename)).To(Equal("parser.go"))
+		Expect(pos.Line).To(Equal(4))
+	})
+})
@@ -149,7 +149,7 @@ func (s *walkSymlinkRaceState) scanCallbackForRaceSinks(fn *ssa.Function, pathPa
 				if idx >= len(common.Args) {
 					continue
 				}
-				if pathDependsOn(common.Args[idx], pathParam, 0, map[ssa.Value]struct{}{}) {
+				if s.pathDependsOn(common.Args[idx], pathParam, 0, map[ssa.Value]struct{}{}) {
 					s.addIssue(instr.Pos())
 					break
 				}
@@ -259,8 +259,8 @@ func isStringType(t types.Type) bool {
 	return basic.Kind() == types.String
 }
 
-func pathDependsOn(value ssa.Value, target ssa.Value, depth int, visited map[ssa.Value]struct{}) bool {
-	if value == nil || target == nil || depth > MaxDepth {
+func (s *walkSymlinkRaceState) pathDependsOn(value ssa.Value, target ssa.Value, depth int, visited map[ssa.Value]struct{}) bool {
+	if value == nil || target == nil || s.DepthExceeded(depth) {
 		return false
 	}
 	if value == target {
@@ -271,29 +271,29 @@ func pathDependsOn(value ssa.Value, target ssa.Value, depth int, visited map[ssa
 	}
 	visited[value] = struct{}{}
 
-	if valueDependsOn(value, target, depth) {
+	if s.valueDependsOn(value, target, depth) {
 		return true
 	}
 
 	switch v := value.(type) {
 	case *ssa.BinOp:
-		return pathDependsOn(v.X, target, depth+1, visited) || pathDependsOn(v.Y, target, depth+1, visited)
+		return s.pathDependsOn(v.X, target, depth+1, visited) || s.pathDependsOn(v.Y, target, depth+1, visited)
 	case *ssa.Convert:
-		return pathDependsOn(v.X, target, depth+1, visited)
+		return s.pathDependsOn(v.X, target, depth+1, visited)
 	case *ssa.UnOp:
-		if pathDependsOn(v.X, target, depth+1, visited) {
+		if s.pathDependsOn(v.X, target, depth+1, visited) {
 			return true
 		}
 		if v.Op == token.MUL {
 			for _, stored := range storedValues(v.X) {
-				if pathDependsOn(stored, target, depth+1, visited) {
+				if s.pathDependsOn(stored, target, depth+1, visited) {
 					return true
 				}
 			}
 		}
 	case *ssa.Call:
 		for _, arg := range v.Call.Args {
-			if pathDependsOn(arg, target, depth+1, visited) {
+			if s.pathDependsOn(arg, target, depth+1, visited) {
 				return true
 			}
 		}
@@ -23,19 +23,9 @@ import (
 // XSS returns a configuration for detecting Cross-Site Scripting vulnerabilities.
 func XSS() taint.Config {
 	return taint.Config{
-		Sources: []taint.Source{
-			// Type sources: tainted when received as parameters
-			{Package: "net/http", Name: "Request", Pointer: true},
-			{Package: "net/url", Name: "Values"},
-
-			// Function sources
-			{Package: "os", Name: "Args", IsFunc: true},
-
-			// I/O sources
-			{Package: "bufio", Name: "Reader", Pointer: true},
-			{Package: "bufio", Name: "Scanner", Pointer: true},
-		},
-		Sinks: []taint.Sink{
+		Sources: taint.DefaultSources,
+		// Environment variables are set by the operator, not by the visitor of the page.
+		Sinks: taint.WithLabels(taint.LabelHTTPInput|taint.LabelNetwork|taint.LabelCLI|taint.LabelQuery, []taint.Sink{
 			// Direct write on the response writer itself — receiver already scopes it.
 			{Package: "net/http", Receiver: "ResponseWriter", Method: "Write"},
 			// fmt print family: arg[0] is the io.Writer target; args[1..n] are the
@@ -72,7 +62,7 @@ func XSS() taint.Config {
 			{Package: "html/template", Method: "HTMLAttr"},
 			{Package: "html/template", Method: "JS"},
 			{Package: "html/template", Method: "CSS"},
-		},
+		}),
 		Sanitizers: []taint.Sanitizer{
 			// html.EscapeString escapes HTML special characters
 			{Package: "html", Method: "EscapeString"},
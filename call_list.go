@@ -15,6 +15,7 @@ package gosec
 
 import (
 	"go/ast"
+	"path"
 	"strings"
 )
 
@@ -69,6 +70,44 @@ func (c CallList) ContainsPointer(selector, indent string) bool {
 	return false
 }
 
+// ContainsMatch returns true if the selector and call match one of the
+// patterns of this call list. Patterns follow the path.Match syntax, so that
+// "github.com/org/log.*" matches all the types of a package as well as the
+// package itself, and "*" all the functions of a selector. Like
+// ContainsPointer, a pointer selector also matches the patterns of the type
+// it points to.
+func (c CallList) ContainsMatch(selector, ident string) bool {
+	if c.Contains(selector, ident) || c.ContainsPointer(selector, ident) {
+		return true
+	}
+	for selectorPattern, idents := range c {
+		if !matchSelector(selectorPattern, selector) && !matchSelector(selectorPattern, strings.TrimPrefix(selector, "*")) {
+			continue
+		}
+		for identPattern := range idents {
+			if matchPattern(identPattern, ident) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchSelector matches a selector pattern against a type or, for patterns
+// ending with ".*", against the bare import path of package functions.
+func matchSelector(pattern, selector string) bool {
+	if matchPattern(pattern, selector) {
+		return true
+	}
+	pkgPattern, ok := strings.CutSuffix(pattern, ".*")
+	return ok && matchPattern(pkgPattern, selector)
+}
+
+func matchPattern(pattern, name string) bool {
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
+
 // ContainsPkgCallExpr resolves the call expression name and type, and then further looks
 // up the package path for that type. Finally, it determines if the call exists within the call list
 func (c CallList) ContainsPkgCallExpr(n ast.Node, ctx *Context, stripVendor bool) *ast.CallExpr {
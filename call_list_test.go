@@ -59,6 +59,25 @@ var _ = Describe("Call List", func() {
 		Expect(actual).Should(BeTrue())
 	})
 
+	It("should match calls with glob patterns", func() {
+		calls.Add("github.com/org/log.*", "*")
+		calls.Add("os", "Set*")
+		Expect(calls.ContainsMatch("*github.com/org/log.Logger", "Info")).Should(BeTrue())
+		Expect(calls.ContainsMatch("os", "Setenv")).Should(BeTrue())
+		Expect(calls.ContainsMatch("os", "Remove")).Should(BeFalse())
+		Expect(calls.ContainsMatch("github.com/org/log/sub.Logger", "Info")).Should(BeFalse())
+	})
+
+	It("should match package functions with a package glob", func() {
+		calls.Add("github.com/org/log.*", "*")
+		calls.Add("os.*", "Remove*")
+		Expect(calls.ContainsMatch("github.com/org/log", "Printf")).Should(BeTrue())
+		Expect(calls.ContainsMatch("os", "Remove")).Should(BeTrue())
+		Expect(calls.ContainsMatch("*os.File", "Remove")).Should(BeTrue())
+		Expect(calls.ContainsMatch("os", "Setenv")).Should(BeFalse())
+		Expect(calls.ContainsMatch("github.com/org/log/sub", "Printf")).Should(BeFalse())
+	})
+
 	It("should not return a match if none are present", func() {
 		calls.Add("ioutil", "Copy")
 		Expect(calls.Contains("fmt", "Println")).Should(BeFalse())
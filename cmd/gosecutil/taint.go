@@ -0,0 +1,288 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/analyzers"
+	"github.com/securego/gosec/v2/taint"
+)
+
+// Options of the taint tool
+var (
+	taintRule   string
+	taintFormat string
+)
+
+// taintRules maps the taint rules to their configuration.
+var taintRules = map[string]func() taint.Config{
+	"G120": analyzers.FormParsingLimits,
+	"G701": analyzers.SQLInjection,
+	"G702": analyzers.CommandInjection,
+	"G703": analyzers.PathTraversal,
+	"G704": analyzers.SSRF,
+	"G705": analyzers.XSS,
+	"G706": analyzers.LogInjection,
+	"G707": analyzers.SMTPInjection,
+	"G708": analyzers.SSTI,
+	"G709": analyzers.UnsafeDeserialization,
+	"G710": analyzers.OpenRedirect,
+	"G711": analyzers.HeaderInjection,
+	"G712": analyzers.UnboundedAllocation,
+	"G713": analyzers.UnsafeReflection,
+}
+
+// dumpTaint explains the taint analysis of the sinks at the given positions,
+// written as file.go:line.
+func dumpTaint(positions ...string) {
+	for _, position := range positions {
+		if err := explainTaint(os.Stdout, taintRule, taintFormat, position); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to explain taint at %s: %s\n", position, err) //#nosec G705
+		}
+	}
+}
+
+func explainTaint(w io.Writer, rule, format, position string) error {
+	file, line, err := parsePosition(position)
+	if err != nil {
+		return err
+	}
+	rules, err := selectTaintRules(rule)
+	if err != nil {
+		return err
+	}
+	if format != "" && format != "tree" && format != "dot" {
+		return fmt.Errorf("unknown format %q, valid formats are: tree, dot", format)
+	}
+
+	prog, srcFuncs, err := loadTaintPackage(file)
+	if err != nil {
+		return err
+	}
+	match := func(call ssa.CallInstruction) bool {
+		pos := prog.Fset.Position(call.Pos())
+		return pos.Line == line && sameFile(pos.Filename, file)
+	}
+
+	var traces []ruleTrace
+	for _, id := range rules {
+		config := taintRules[id]()
+		for _, trace := range taint.New(&config).Explain(prog, srcFuncs, match) {
+			traces = append(traces, ruleTrace{rule: id, SinkTrace: trace})
+		}
+	}
+	if len(traces) == 0 {
+		return fmt.Errorf("no sink of %s at line %d", strings.Join(rules, ", "), line)
+	}
+
+	if format == "dot" {
+		writeTaintDOT(w, prog.Fset, traces)
+	} else {
+		writeTaintTree(w, prog.Fset, traces)
+	}
+	return nil
+}
+
+type ruleTrace struct {
+	rule string
+	taint.SinkTrace
+}
+
+func parsePosition(position string) (string, int, error) {
+	idx := strings.LastIndex(position, ":")
+	if idx < 0 {
+		return "", 0, fmt.Errorf("expected file.go:line, got %q", position)
+	}
+	line, err := strconv.Atoi(position[idx+1:])
+	if err != nil || line <= 0 {
+		return "", 0, fmt.Errorf("invalid line in %q", position)
+	}
+	file, err := filepath.Abs(position[:idx])
+	if err != nil {
+		return "", 0, err
+	}
+	return file, line, nil
+}
+
+func selectTaintRules(rule string) ([]string, error) {
+	if rule != "" {
+		if _, ok := taintRules[rule]; !ok {
+			return nil, fmt.Errorf("%s is not a taint rule", rule)
+		}
+		return []string{rule}, nil
+	}
+	rules := make([]string, 0, len(taintRules))
+	for id := range taintRules {
+		rules = append(rules, id)
+	}
+	sort.Strings(rules)
+	return rules, nil
+}
+
+func sameFile(a, b string) bool {
+	if a == b {
+		return true
+	}
+	sa, errA := os.Stat(a)
+	sb, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(sa, sb)
+}
+
+// loadTaintPackage builds the SSA of the package containing file and returns
+// its functions, including anonymous functions. Like gosec, it only builds the
+// package itself: dependencies come from export data and have no bodies.
+func loadTaintPackage(file string) (*ssa.Program, []*ssa.Function, error) {
+	config := &packages.Config{
+		Mode:  gosec.LoadMode,
+		Dir:   filepath.Dir(file),
+		Tests: strings.HasSuffix(file, "_test.go"),
+	}
+	pkgs, err := packages.Load(config, "file="+file)
+	if err != nil {
+		return nil, nil, err
+	}
+	if packages.PrintErrors(pkgs) > 0 || len(pkgs) == 0 {
+		return nil, nil, fmt.Errorf("failed to load the package of %s", file)
+	}
+	pkg := pkgs[0]
+
+	prog := ssa.NewProgram(pkg.Fset, 0)
+	for _, imp := range pkg.Types.Imports() {
+		prog.CreatePackage(imp, nil, nil, true)
+	}
+	ssaPkg := prog.CreatePackage(pkg.Types, pkg.Syntax, pkg.TypesInfo, false)
+	ssaPkg.Build()
+
+	var srcFuncs []*ssa.Function
+	var addAnons func(fn *ssa.Function)
+	addAnons = func(fn *ssa.Function) {
+		srcFuncs = append(srcFuncs, fn)
+		for _, anon := range fn.AnonFuncs {
+			addAnons(anon)
+		}
+	}
+	for _, f := range pkg.Syntax {
+		for _, decl := range f.Decls {
+			fdecl, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			if obj, ok := pkg.TypesInfo.Defs[fdecl.Name].(*types.Func); ok {
+				if fn := prog.FuncValue(obj); fn != nil {
+					addAnons(fn)
+				}
+			}
+		}
+	}
+	return prog, srcFuncs, nil
+}
+
+func writeTaintTree(w io.Writer, fset *token.FileSet, traces []ruleTrace) {
+	for _, trace := range traces {
+		fmt.Fprintf(w, "%s %s\n", trace.rule, traceTitle(fset, trace))
+		if trace.Root != nil {
+			writeTraceNode(w, fset, trace.Root, "  ")
+		}
+	}
+}
+
+func writeTraceNode(w io.Writer, fset *token.FileSet, node *taint.TraceNode, indent string) {
+	fmt.Fprintf(w, "%s%s\n", indent, nodeLabel(fset, node))
+	for _, child := range node.Children {
+		writeTraceNode(w, fset, child, indent+"  ")
+	}
+}
+
+func writeTaintDOT(w io.Writer, fset *token.FileSet, traces []ruleTrace) {
+	fmt.Fprintln(w, "digraph taint {")
+	fmt.Fprintln(w, "  node [shape=box];")
+	id := 0
+	var walk func(node *taint.TraceNode) int
+	walk = func(node *taint.TraceNode) int {
+		self := id
+		id++
+		attrs := ""
+		switch {
+		case node.Tainted:
+			attrs = ", color=red"
+		case node.Cutoff != "":
+			attrs = ", style=dashed"
+		}
+		fmt.Fprintf(w, "  n%d [label=%s%s];\n", self, strconv.Quote(nodeLabel(fset, node)), attrs)
+		for _, child := range node.Children {
+			fmt.Fprintf(w, "  n%d -> n%d;\n", self, walk(child))
+		}
+		return self
+	}
+	for _, trace := range traces {
+		sink := id
+		id++
+		fmt.Fprintf(w, "  n%d [label=%s, shape=doubleoctagon];\n", sink, strconv.Quote(trace.rule+" "+traceTitle(fset, trace)))
+		if trace.Root != nil {
+			fmt.Fprintf(w, "  n%d -> n%d;\n", sink, walk(trace.Root))
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+func traceTitle(fset *token.FileSet, trace ruleTrace) string {
+	title := fmt.Sprintf("%s at %s", trace.Call.Common(), fset.Position(trace.Call.Pos()))
+	if trace.Arg >= 0 {
+		title += fmt.Sprintf(", argument %d", trace.Arg)
+	}
+	switch {
+	case trace.Skipped != "":
+		title += ": skipped, " + trace.Skipped
+	case trace.Root != nil && trace.Root.Tainted:
+		title += ": tainted"
+	default:
+		title += ": clean"
+	}
+	return title
+}
+
+func nodeLabel(fset *token.FileSet, node *taint.TraceNode) string {
+	label := node.Value.String()
+	if _, ok := node.Value.(ssa.Instruction); ok && node.Value.Name() != "" {
+		label = node.Value.Name() + " = " + label
+	}
+	if node.Func != nil {
+		label += " in " + node.Func.Name()
+	}
+	if pos := node.Value.Pos(); pos.IsValid() {
+		label += " (" + fset.Position(pos).String() + ")"
+	}
+	if node.Tainted {
+		label += " [tainted]"
+	}
+	if node.Cutoff != "" {
+		label += " [cut: " + string(node.Cutoff) + "]"
+	}
+	return label
+}
@@ -44,6 +44,7 @@ func newUtils() *utilities {
 	utils["defs"] = dumpDefs
 	utils["comments"] = dumpComments
 	utils["imports"] = dumpImports
+	utils["taint"] = dumpTaint
 	return &utilities{utils, make([]string, 0)}
 }
 
@@ -279,6 +280,8 @@ func dumpImports(files ...string) {
 func main() {
 	tools := newUtils()
 	flag.Var(tools, "tool", "Utils to assist with rule development")
+	flag.StringVar(&taintRule, "rule", "", "Taint rule explained by the taint tool, all taint rules if empty")
+	flag.StringVar(&taintFormat, "format", "tree", "Output of the taint tool: tree or dot")
 	flag.Parse()
 
 	if len(tools.call) > 0 {
@@ -21,7 +21,7 @@ var _ = Describe("newUtils", func() {
 	It("should create utilities with all commands", func() {
 		utils := newUtils()
 		Expect(utils).NotTo(BeNil())
-		Expect(utils.commands).To(HaveLen(7))
+		Expect(utils.commands).To(HaveLen(8))
 		Expect(utils.commands).To(HaveKey("ast"))
 		Expect(utils.commands).To(HaveKey("callobj"))
 		Expect(utils.commands).To(HaveKey("uses"))
@@ -29,6 +29,7 @@ var _ = Describe("newUtils", func() {
 		Expect(utils.commands).To(HaveKey("defs"))
 		Expect(utils.commands).To(HaveKey("comments"))
 		Expect(utils.commands).To(HaveKey("imports"))
+		Expect(utils.commands).To(HaveKey("taint"))
 		Expect(utils.call).To(BeEmpty())
 	})
 })
@@ -44,12 +45,13 @@ var _ = Describe("utilities.String", func() {
 		Expect(str).To(ContainSubstring("defs"))
 		Expect(str).To(ContainSubstring("comments"))
 		Expect(str).To(ContainSubstring("imports"))
+		Expect(str).To(ContainSubstring("taint"))
 	})
 
 	It("should contain commas between commands", func() {
 		utils := newUtils()
 		str := utils.String()
-		Expect(strings.Count(str, ",")).To(Equal(6)) // 7 commands = 6 commas
+		Expect(strings.Count(str, ",")).To(Equal(7)) // 8 commands = 7 commas
 	})
 })
 
@@ -85,12 +87,12 @@ var _ = Describe("utilities.Set", func() {
 	})
 
 	It("should accept all valid commands", func() {
-		validCommands := []string{"ast", "callobj", "uses", "types", "defs", "comments", "imports"}
+		validCommands := []string{"ast", "callobj", "uses", "types", "defs", "comments", "imports", "taint"}
 		for _, cmd := range validCommands {
 			err := utils.Set(cmd)
 			Expect(err).NotTo(HaveOccurred())
 		}
-		Expect(utils.call).To(HaveLen(7))
+		Expect(utils.call).To(HaveLen(8))
 	})
 })
 
@@ -862,6 +864,75 @@ func main() {
 	})
 })
 
+var _ = Describe("explainTaint", func() {
+	var tempDir, file string
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "test-taint-*")
+		Expect(err).NotTo(HaveOccurred())
+		file = filepath.Join(tempDir, "main.go")
+		err = os.WriteFile(file, []byte(`package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	_, _ = os.ReadFile(r.FormValue("name"))
+	_, _ = os.ReadFile(filepath.Clean(r.FormValue("name")))
+}
+
+func main() {}
+`), 0o600)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("should print the explored values as a tree", func() {
+		var buf bytes.Buffer
+		err := explainTaint(&buf, "G703", "tree", file+":10")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.String()).To(ContainSubstring("G703 os.ReadFile"))
+		Expect(buf.String()).To(ContainSubstring("argument 0: tainted"))
+		Expect(buf.String()).To(ContainSubstring("parameter r : *net/http.Request in handler"))
+	})
+
+	It("should show where a sanitizer cut the exploration", func() {
+		var buf bytes.Buffer
+		err := explainTaint(&buf, "G703", "tree", file+":11")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.String()).To(ContainSubstring("argument 0: clean"))
+		Expect(buf.String()).To(ContainSubstring("[cut: sanitizer]"))
+	})
+
+	It("should print the explored values as Graphviz DOT", func() {
+		var buf bytes.Buffer
+		err := explainTaint(&buf, "G703", "dot", file+":10")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.String()).To(HavePrefix("digraph taint {"))
+		Expect(buf.String()).To(ContainSubstring("color=red"))
+		Expect(buf.String()).To(ContainSubstring("->"))
+	})
+
+	It("should return an error when no sink is at the position", func() {
+		err := explainTaint(io.Discard, "", "tree", file+":14")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("no sink"))
+	})
+
+	It("should reject invalid arguments", func() {
+		Expect(explainTaint(io.Discard, "G703", "tree", file)).To(HaveOccurred())
+		Expect(explainTaint(io.Discard, "G101", "tree", file+":10")).To(HaveOccurred())
+		Expect(explainTaint(io.Discard, "G703", "svg", file+":10")).To(HaveOccurred())
+	})
+})
+
 var _ = Describe("Integration tests", func() {
 	It("should handle complete workflow", func() {
 		// Create test file
@@ -42,6 +42,16 @@ const (
 	// without a justification no longer suppress any findings and an error is
 	// reported instead.
 	NoSecRequireJustification GlobalOption = "nosec-require-justification"
+	// SSAMaxDepth global option bounds the recursion depth of the SSA analyzers
+	SSAMaxDepth GlobalOption = "ssa-max-depth"
+	// TaintMaxDepth global option bounds the values explored back from a taint sink
+	TaintMaxDepth GlobalOption = "taint-max-depth"
+	// TaintMaxCallerEdges global option bounds the callers explored for each
+	// parameter reached by the taint analysis
+	TaintMaxCallerEdges GlobalOption = "taint-max-caller-edges"
+	// SSAAnalyzerTimeout global option sets the wall-clock budget of the SSA
+	// analyzers for each package, as a duration such as "30s"
+	SSAAnalyzerTimeout GlobalOption = "ssa-analyzer-timeout"
 )
 
 // NoSecTag returns the tag used to disable gosec for a line of code.
@@ -53,6 +53,11 @@ var idWeaknesses = map[string]*Weakness{
 		Description: "The software constructs all or part of a code segment using externally-influenced input from an upstream component, but it does not neutralize or incorrectly neutralizes special elements that could modify the syntax or behavior of the intended code segment.",
 		Name:        "Improper Control of Generation of Code ('Code Injection')",
 	},
+	"113": {
+		ID:          "113",
+		Description: "The product receives data from an HTTP agent/component, but it does not neutralize or incorrectly neutralizes CR and LF characters before the data is included in outgoing HTTP headers.",
+		Name:        "Improper Neutralization of CRLF Sequences in HTTP Headers ('HTTP Request/Response Splitting')",
+	},
 	"118": {
 		ID:          "118",
 		Description: "The software does not restrict or incorrectly restricts operations within the boundaries of a resource that is accessed using an index or pointer, such as memory or files.",
@@ -73,6 +78,11 @@ var idWeaknesses = map[string]*Weakness{
 		Description: "The program calls a function that can never be guaranteed to work safely.",
 		Name:        "Use of Inherently Dangerous Function",
 	},
+	"252": {
+		ID:          "252",
+		Description: "The product does not check the return value from a method or function, which can prevent it from detecting unexpected states and conditions.",
+		Name:        "Unchecked Return Value",
+	},
 	"276": {
 		ID:          "276",
 		Description: "During installation, installed file permissions are set to allow anyone to modify those files.",
@@ -143,6 +153,11 @@ var idWeaknesses = map[string]*Weakness{
 		Description: "When malformed or unexpected HTTP requests are inconsistently interpreted by one or more entities in the data flow between the user and the web server, such as a proxy or firewall, attackers can abuse this discrepancy to smuggle requests to one system without the other system being aware of it.",
 		Name:        "Inconsistent Interpretation of HTTP Requests ('HTTP Request Smuggling')",
 	},
+	"470": {
+		ID:          "470",
+		Description: "The product uses external input with reflection to select which classes or code to use, but it does not sufficiently prevent the input from selecting improper classes or code.",
+		Name:        "Use of Externally-Controlled Input to Select Classes or Code ('Unsafe Reflection')",
+	},
 	"499": {
 		ID:          "499",
 		Description: "The code contains a class with sensitive data, but the class does not explicitly deny serialization. The data can be accessed by serializing the class through another class.",
@@ -158,6 +173,11 @@ var idWeaknesses = map[string]*Weakness{
 		Description: "The software does not properly anticipate or handle exceptional conditions that rarely occur during normal operation of the software.",
 		Name:        "Improper Check or Handling of Exceptional Conditions",
 	},
+	"789": {
+		ID:          "789",
+		Description: "The product allocates memory based on an untrusted, large size value, but it does not ensure that the size is within expected limits, allowing arbitrary amounts of memory to be allocated.",
+		Name:        "Memory Allocation with Excessive Size Value",
+	},
 	"798": {
 		ID:          "798",
 		Description: "The software contains hard-coded credentials, such as a password or cryptographic key, which it uses for its own inbound authentication, outbound communication to external components, or encryption of internal data.",
@@ -178,11 +198,26 @@ var idWeaknesses = map[string]*Weakness{
 		Description: "The application deserializes untrusted data without sufficiently verifying that the resulting data will be valid.",
 		Name:        "Deserialization of Untrusted Data",
 	},
+	"526": {
+		ID:          "526",
+		Description: "The product uses an environment variable to store unencrypted sensitive information.",
+		Name:        "Cleartext Storage of Sensitive Information in an Environment Variable",
+	},
+	"532": {
+		ID:          "532",
+		Description: "Information written to log files can be of a sensitive nature and give valuable guidance to an attacker or expose sensitive user information.",
+		Name:        "Insertion of Sensitive Information into Log File",
+	},
 	"614": {
 		ID:          "614",
 		Description: "The Secure attribute for a sensitive cookie is not set, which could cause the user agent to send that cookie in plaintext over an HTTP session.",
 		Name:        "Sensitive Cookie in HTTPS Session Without 'Secure' Attribute",
 	},
+	"915": {
+		ID:          "915",
+		Description: "The product receives input from an upstream component that specifies multiple attributes, properties, or fields that are to be initialized or updated in an object, but it does not properly control which attributes can be modified.",
+		Name:        "Improperly Controlled Modification of Dynamically-Determined Object Attributes",
+	},
 	"918": {
 		ID:          "918",
 		Description: "The web server receives a URL or similar request from an upstream component and retrieves the contents of this URL, but it does not sufficiently ensure that the request is being sent to the expected destination.",
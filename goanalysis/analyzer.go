@@ -31,16 +31,19 @@ import (
 	"github.com/securego/gosec/v2/analyzers"
 	"github.com/securego/gosec/v2/issue"
 	"github.com/securego/gosec/v2/rules"
+	"github.com/securego/gosec/v2/taint"
 )
 
 const Doc = `gosec is a static analysis tool that scans Go code for security problems.`
 
-// Analyzer is the standard go/analysis Analyzer for gosec.
+// Analyzer is the standard go/analysis Analyzer for gosec. It exports taint
+// summaries as facts so that taint flows are followed across packages.
 var Analyzer = &analysis.Analyzer{
-	Name:     "gosec",
-	Doc:      Doc,
-	Run:      run,
-	Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	Name:      "gosec",
+	Doc:       Doc,
+	Run:       run,
+	Requires:  []*analysis.Analyzer{buildssa.Analyzer},
+	FactTypes: []analysis.Fact{new(taint.FunctionFact)},
 }
 
 var (
@@ -65,6 +68,7 @@ func run(pass *analysis.Pass) (any, error) {
 	config := gosec.NewConfig()
 	logger := log.New(io.Discard, "", 0) // Discard gosec's verbose logging
 	gosecAnalyzer := gosec.NewAnalyzer(config, false, flagExcludeGenerated, false, 1, logger)
+	gosecAnalyzer.SetObjectFacts(pass.ImportObjectFact, pass.ExportObjectFact)
 
 	// Build filters from include/exclude flags
 	ruleFilters := buildFilters(flagIncludeRules, flagExcludeRules, rules.NewRuleFilter)
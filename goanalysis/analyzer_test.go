@@ -25,3 +25,7 @@ import (
 func TestAnalyzer(t *testing.T) {
 	analysistest.Run(t, analysistest.TestData(), goanalysis.Analyzer, "a")
 }
+
+func TestAnalyzerCrossPackageTaint(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), goanalysis.Analyzer, "api")
+}
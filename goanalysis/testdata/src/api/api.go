@@ -0,0 +1,13 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"store"
+)
+
+func Search(db *sql.DB, r *http.Request) { // want Search:`taint\(G701\{sinks:\[1->\(\*database/sql.DB\).Query\]`
+	_, _ = store.FindUser(db, r.URL.Query().Get("name")) // want `G701: \[CWE-89\] SQL injection via taint analysis`
+	_, _ = store.FindUserSafe(db, r.URL.Query().Get("name"))
+}
@@ -0,0 +1,14 @@
+package store
+
+import "database/sql"
+
+// FindUser builds a query from its argument; it is only vulnerable when the
+// caller passes untrusted input.
+func FindUser(db *sql.DB, name string) (*sql.Rows, error) {
+	return db.Query("SELECT * FROM users WHERE name = '" + name + "'")
+}
+
+// FindUserSafe uses a parameterized query.
+func FindUserSafe(db *sql.DB, name string) (*sql.Rows, error) {
+	return db.Query("SELECT * FROM users WHERE name = ?", name)
+}
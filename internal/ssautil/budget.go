@@ -0,0 +1,85 @@
+package ssautil
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Reasons of a truncated analysis. They are the names of the global options
+// controlling the budget that was exhausted.
+const (
+	TruncatedMaxDepth         = "ssa-max-depth"
+	TruncatedTaintMaxDepth    = "taint-max-depth"
+	TruncatedTaintCallerEdges = "taint-max-caller-edges"
+	TruncatedTimeout          = "ssa-analyzer-timeout"
+)
+
+// Budget bounds the work of the SSA analyzers on a package and records the
+// analyzers whose analysis stopped short because a bound was reached. It is
+// shared by the analyzers of the package and safe for concurrent use.
+type Budget struct {
+	// MaxDepth bounds the recursion of the SSA analyzers, 0 selects their default.
+	MaxDepth int
+	// TaintMaxDepth bounds the values explored back from a taint sink, 0
+	// selects the default of the taint engine.
+	TaintMaxDepth int
+	// TaintMaxCallerEdges bounds the callers explored for each parameter by
+	// the taint engine, 0 selects its default.
+	TaintMaxCallerEdges int
+	// Deadline ends the wall-clock budget of the package, zero for none.
+	Deadline time.Time
+
+	mu        sync.Mutex
+	truncated map[string]map[string]bool
+}
+
+// Truncation lists the budgets exhausted by an analyzer.
+type Truncation struct {
+	Analyzer string
+	Reasons  []string
+}
+
+// Expired reports whether the wall-clock budget of the package is spent.
+func (b *Budget) Expired() bool {
+	return b != nil && !b.Deadline.IsZero() && time.Now().After(b.Deadline)
+}
+
+// Truncate records that the analysis of analyzer was truncated for reason.
+func (b *Budget) Truncate(analyzer, reason string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.truncated == nil {
+		b.truncated = make(map[string]map[string]bool)
+	}
+	if b.truncated[analyzer] == nil {
+		b.truncated[analyzer] = make(map[string]bool)
+	}
+	b.truncated[analyzer][reason] = true
+}
+
+// Truncations returns the truncated analyzers sorted by name, with their
+// reasons sorted.
+func (b *Budget) Truncations() []Truncation {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	truncations := make([]Truncation, 0, len(b.truncated))
+	for analyzer, reasons := range b.truncated {
+		t := Truncation{Analyzer: analyzer}
+		for reason := range reasons {
+			t.Reasons = append(t.Reasons, reason)
+		}
+		sort.Strings(t.Reasons)
+		truncations = append(truncations, t)
+	}
+	sort.Slice(truncations, func(i, j int) bool {
+		return truncations[i].Analyzer < truncations[j].Analyzer
+	})
+	return truncations
+}
@@ -15,6 +15,9 @@ type PackageAnalysisCache struct {
 
 	callGraphOnce sync.Once
 	callGraph     *callgraph.Graph
+
+	valuesMu sync.Mutex
+	values   map[any]any
 }
 
 // NewPackageAnalysisCache builds a cache object for a package-level SSA result.
@@ -38,3 +41,25 @@ func (c *PackageAnalysisCache) CallGraph() *callgraph.Graph {
 
 	return c.callGraph
 }
+
+// Value returns the artifact stored under key, calling build to create it on
+// first use. It lets analyzers share state that this package cannot build
+// itself without import cycles. It is safe for concurrent use.
+func (c *PackageAnalysisCache) Value(key any, build func() any) any {
+	if c == nil {
+		return build()
+	}
+
+	c.valuesMu.Lock()
+	defer c.valuesMu.Unlock()
+
+	if v, ok := c.values[key]; ok {
+		return v
+	}
+	if c.values == nil {
+		c.values = make(map[any]any)
+	}
+	v := build()
+	c.values[key] = v
+	return v
+}
@@ -135,4 +135,27 @@ func main() {
 			Expect(graphs[i]).To(BeIdenticalTo(graphs[0]))
 		}
 	})
+
+	It("builds values once per key", func() {
+		cache := ssautil.NewPackageAnalysisCache(nil)
+		type key struct{ name string }
+
+		builds := 0
+		build := func() any {
+			builds++
+			return &builds
+		}
+
+		first := cache.Value(key{"a"}, build)
+		Expect(cache.Value(key{"a"}, build)).To(BeIdenticalTo(first))
+		Expect(builds).To(Equal(1))
+
+		cache.Value(key{"b"}, build)
+		Expect(builds).To(Equal(2))
+	})
+
+	It("builds values without caching for nil receiver", func() {
+		var cache *ssautil.PackageAnalysisCache
+		Expect(cache.Value("k", func() any { return 1 })).To(Equal(1))
+	})
 })
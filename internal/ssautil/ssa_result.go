@@ -21,6 +21,10 @@ type SSAAnalyzerResult struct {
 	Logger *log.Logger
 	SSA    *buildssa.SSA
 	Shared *PackageAnalysisCache
+	Budget *Budget
+	// Audit reports whether gosec runs in audit mode. Audit-only analyzers
+	// report nothing otherwise.
+	Audit bool
 }
 
 // GetSSAResult retrieves the SSA result from analysis pass
@@ -82,6 +82,12 @@ var ruleToCWE = map[string]string{
 	"G122": "367",
 	"G123": "295",
 	"G124": "614",
+	"G125": "915",
+	"G126": "532",
+	"G127": "400",
+	"G128": "252",
+	"G129": "242",
+	"G130": "526",
 	"G201": "89",
 	"G202": "89",
 	"G203": "79",
@@ -93,6 +99,8 @@ var ruleToCWE = map[string]string{
 	"G305": "22",
 	"G306": "276",
 	"G307": "276",
+	"G308": "22",
+	"G309": "367",
 	"G401": "328",
 	"G402": "295",
 	"G403": "310",
@@ -101,6 +109,7 @@ var ruleToCWE = map[string]string{
 	"G406": "328",
 	"G407": "1204",
 	"G408": "287",
+	"G409": "295",
 	"G501": "327",
 	"G502": "327",
 	"G503": "327",
@@ -110,6 +119,7 @@ var ruleToCWE = map[string]string{
 	"G507": "327",
 	"G601": "118",
 	"G602": "118",
+	"G603": "190",
 	"G701": "89",
 	"G702": "78",
 	"G703": "22",
@@ -117,6 +127,9 @@ var ruleToCWE = map[string]string{
 	"G705": "79",
 	"G706": "117",
 	"G710": "601",
+	"G711": "113",
+	"G712": "789",
+	"G713": "470",
 }
 
 // Issue is returned by a gosec rule if it discovers an issue with the scanned code.
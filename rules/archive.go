@@ -57,6 +57,10 @@ func (a *archive) Match(n ast.Node, ctx *gosec.Context) (*issue.Issue, error) {
 		for _, arg := range node.Args {
 			if baseType := getArchiveBaseType(arg, ctx, file); baseType != nil {
 				if slices.Contains(a.argTypes, baseType.String()) {
+					// Entry name validated with filepath.IsLocal before joining → safe
+					if isLocalPathGuarded(node, arg, ctx) {
+						continue
+					}
 					return ctx.NewIssue(n, a.ID(), a.What, a.Severity, a.Confidence), nil
 				}
 			}
@@ -17,6 +17,8 @@ package rules
 import (
 	"go/ast"
 	"go/types"
+	"strconv"
+	"strings"
 
 	"github.com/securego/gosec/v2"
 	"github.com/securego/gosec/v2/issue"
@@ -24,7 +26,17 @@ import (
 
 type noErrorCheck struct {
 	issue.MetaData
-	whitelist gosec.CallList
+	whitelist      gosec.CallList
+	interfaces     gosec.CallList
+	deferred       gosec.CallList
+	deferredIfaces gosec.CallList
+	reportDeferred bool
+	ifaceCache     map[ifaceKey]*types.Interface
+}
+
+type ifaceKey struct {
+	pkg  *types.Package
+	name string
 }
 
 func returnsError(callExpr *ast.CallExpr, ctx *gosec.Context) int {
@@ -52,7 +64,7 @@ func (r *noErrorCheck) Match(n ast.Node, ctx *gosec.Context) (*issue.Issue, erro
 		cfg := ctx.Config
 		if enabled, err := cfg.IsGlobalEnabled(gosec.Audit); err == nil && enabled {
 			for _, expr := range stmt.Rhs {
-				if callExpr, ok := expr.(*ast.CallExpr); ok && r.whitelist.ContainsCallExpr(expr, ctx) == nil {
+				if callExpr, ok := expr.(*ast.CallExpr); ok && !r.isAllowed(callExpr, ctx, false) {
 					pos := returnsError(callExpr, ctx)
 					if pos < 0 || pos >= len(stmt.Lhs) {
 						return nil, nil
@@ -64,16 +76,109 @@ func (r *noErrorCheck) Match(n ast.Node, ctx *gosec.Context) (*issue.Issue, erro
 			}
 		}
 	case *ast.ExprStmt:
-		if callExpr, ok := stmt.X.(*ast.CallExpr); ok && r.whitelist.ContainsCallExpr(stmt.X, ctx) == nil {
+		if callExpr, ok := stmt.X.(*ast.CallExpr); ok && !r.isAllowed(callExpr, ctx, false) {
 			pos := returnsError(callExpr, ctx)
 			if pos >= 0 {
 				return ctx.NewIssue(n, r.ID(), r.What, r.Severity, r.Confidence), nil
 			}
 		}
+	case *ast.DeferStmt:
+		if r.reportDeferred && !r.isAllowed(stmt.Call, ctx, true) {
+			if pos := returnsError(stmt.Call, ctx); pos >= 0 {
+				return ctx.NewIssue(n, r.ID(), r.What, r.Severity, r.Confidence), nil
+			}
+		}
 	}
 	return nil, nil
 }
 
+// isAllowed checks the call against the whitelist, and the deferred whitelist
+// when the call is deferred.
+func (r *noErrorCheck) isAllowed(callExpr *ast.CallExpr, ctx *gosec.Context, deferred bool) bool {
+	if r.whitelist.ContainsCallExpr(callExpr, ctx) != nil || r.implementsAllowed(r.interfaces, callExpr, ctx) ||
+		(deferred && r.implementsAllowed(r.deferredIfaces, callExpr, ctx)) {
+		return true
+	}
+	selector, ident, err := gosec.GetCallInfo(callExpr, ctx)
+	if err != nil {
+		return false
+	}
+	// Package functions are also matched by import path
+	path := selector
+	if !strings.ContainsAny(selector, "./") {
+		if importPath, ok := gosec.GetImportPath(selector, ctx); ok {
+			path = importPath
+		}
+	}
+
+	if r.whitelist.ContainsMatch(selector, ident) || r.whitelist.ContainsMatch(path, ident) {
+		return true
+	}
+	return deferred && (r.deferred.ContainsMatch(selector, ident) || r.deferred.ContainsMatch(path, ident))
+}
+
+// implementsAllowed reports whether the call is a method call on a receiver
+// implementing one of the interfaces whitelisted for the method.
+func (r *noErrorCheck) implementsAllowed(interfaces gosec.CallList, callExpr *ast.CallExpr, ctx *gosec.Context) bool {
+	if len(interfaces) == 0 {
+		return false
+	}
+	sel, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	selection, ok := ctx.Info.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return false
+	}
+	recv := selection.Recv()
+	for name := range interfaces {
+		if !interfaces.ContainsMatch(name, sel.Sel.Name) {
+			continue
+		}
+		iface := r.lookupInterface(name, ctx.Pkg)
+		if iface != nil && (types.Implements(recv, iface) || types.Implements(types.NewPointer(recv), iface)) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupInterface resolves an interface type name, such as "io.Closer", in the
+// packages imported directly or indirectly by pkg.
+func (r *noErrorCheck) lookupInterface(name string, pkg *types.Package) *types.Interface {
+	key := ifaceKey{pkg, name}
+	if iface, ok := r.ifaceCache[key]; ok {
+		return iface
+	}
+	idx := strings.LastIndex(name, ".")
+	if idx < 0 || pkg == nil {
+		return nil
+	}
+	path, typeName := name[:idx], name[idx+1:]
+
+	var iface *types.Interface
+	visited := map[*types.Package]bool{}
+	queue := []*types.Package{pkg}
+	for len(queue) > 0 && iface == nil {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		if current.Path() == path {
+			if obj, ok := current.Scope().Lookup(typeName).(*types.TypeName); ok {
+				iface, _ = obj.Type().Underlying().(*types.Interface)
+			}
+			break
+		}
+		queue = append(queue, current.Imports()...)
+	}
+	r.ifaceCache[key] = iface
+	return iface
+}
+
 // NewNoErrorCheck detects if the returned error is unchecked
 func NewNoErrorCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
 	// TODO(gm) Come up with sensible defaults here. Or flip it to use a
@@ -87,20 +192,64 @@ func NewNoErrorCheck(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
 	whitelist.Add("os", "Unsetenv")
 	whitelist.Add("rand", "Read")
 
+	rule := &noErrorCheck{
+		MetaData:       issue.NewMetaData(id, "Errors unhandled", issue.Low, issue.High),
+		whitelist:      whitelist,
+		interfaces:     gosec.NewCallList(),
+		deferred:       gosec.NewCallList(),
+		deferredIfaces: gosec.NewCallList(),
+		ifaceCache:     make(map[ifaceKey]*types.Interface),
+	}
+
+	// Entries are either a list of functions, or an object with the functions
+	// and whether the selector is an interface and the entry only applies to
+	// deferred calls. The "defer" key sets whether deferred calls are checked.
 	if configured, ok := conf[id]; ok {
 		if whitelisted, ok := configured.(map[string]interface{}); ok {
-			for pkg, funcs := range whitelisted {
-				if funcs, ok := funcs.([]interface{}); ok {
-					whitelist.AddAll(pkg, toStringSlice(funcs)...)
+			for selector, entry := range whitelisted {
+				switch entry := entry.(type) {
+				case []interface{}:
+					whitelist.AddAll(selector, toStringSlice(entry)...)
+				case map[string]interface{}:
+					rule.addEntry(selector, entry)
+				case string:
+					if selector == "defer" {
+						rule.reportDeferred = entry == "report"
+					}
 				}
 			}
 		}
 	}
 
-	return &noErrorCheck{
-		MetaData:  issue.NewMetaData(id, "Errors unhandled", issue.Low, issue.High),
-		whitelist: whitelist,
-	}, []ast.Node{(*ast.AssignStmt)(nil), (*ast.ExprStmt)(nil)}
+	return rule, []ast.Node{(*ast.AssignStmt)(nil), (*ast.ExprStmt)(nil), (*ast.DeferStmt)(nil)}
+}
+
+func (r *noErrorCheck) addEntry(selector string, entry map[string]interface{}) {
+	funcs, _ := entry["functions"].([]interface{})
+	implements := isEnabled(entry["implements"])
+	deferred := isEnabled(entry["deferred"])
+
+	list := r.whitelist
+	switch {
+	case implements && deferred:
+		list = r.deferredIfaces
+	case implements:
+		list = r.interfaces
+	case deferred:
+		list = r.deferred
+	}
+	list.AddAll(selector, toStringSlice(funcs)...)
+}
+
+func isEnabled(value interface{}) bool {
+	switch value := value.(type) {
+	case bool:
+		return value
+	case string:
+		enabled, err := strconv.ParseBool(value)
+		return err == nil && enabled
+	}
+	return false
 }
 
 func toStringSlice(values []interface{}) []string {
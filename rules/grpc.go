@@ -0,0 +1,334 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"log"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+)
+
+const (
+	grpcPkg            = "google.golang.org/grpc"
+	grpcCredentialsPkg = "google.golang.org/grpc/credentials"
+	grpcInsecurePkg    = "google.golang.org/grpc/credentials/insecure"
+)
+
+// Looks for gRPC clients and servers constructed without transport security
+type grpcTransportSecurity struct {
+	issue.MetaData
+	allowedHosts []*regexp.Regexp
+}
+
+var grpcDialFuncs = []string{"Dial", "DialContext", "NewClient"}
+
+func (r *grpcTransportSecurity) Match(n ast.Node, ctx *gosec.Context) (*issue.Issue, error) {
+	if _, ok := gosec.MatchCallByPackage(n, ctx, grpcPkg, "WithInsecure"); ok {
+		if r.isAllowedDial(n, ctx) {
+			return nil, nil
+		}
+		return ctx.NewIssue(n, r.ID(), "gRPC client uses grpc.WithInsecure, which disables transport security", issue.High, issue.High), nil
+	}
+
+	if _, ok := gosec.MatchCallByPackage(n, ctx, grpcInsecurePkg, "NewCredentials"); ok {
+		if r.isAllowedDial(n, ctx) {
+			return nil, nil
+		}
+		return ctx.NewIssue(n, r.ID(), "gRPC connection uses insecure.NewCredentials, which disables transport security", issue.High, issue.High), nil
+	}
+
+	if callExpr, ok := gosec.MatchCallByPackage(n, ctx, grpcCredentialsPkg, "NewTLS"); ok {
+		if len(callExpr.Args) == 0 || !isInsecureSkipVerifyConfig(callExpr.Args[0], ctx) {
+			return nil, nil
+		}
+		if r.isAllowedDial(n, ctx) {
+			return nil, nil
+		}
+		return ctx.NewIssue(n, r.ID(), "gRPC TLS credentials are created with InsecureSkipVerify set to true", issue.High, issue.High), nil
+	}
+
+	if callExpr, ok := gosec.MatchCallByPackage(n, ctx, grpcPkg, "NewServer"); ok {
+		resolved := true
+		visited := make(map[types.Object]bool)
+		for _, arg := range callExpr.Args {
+			found, ok := hasGRPCCreds(arg, ctx, visited)
+			if found {
+				return nil, nil
+			}
+			resolved = resolved && ok
+		}
+		confidence := issue.Medium
+		if !resolved {
+			// Some options come from values that cannot be inspected here.
+			confidence = issue.Low
+		}
+		return ctx.NewIssue(n, r.ID(), "gRPC server created without transport credentials (grpc.Creds)", issue.Medium, confidence), nil
+	}
+
+	return nil, nil
+}
+
+// isAllowedDial reports whether n is an argument of a grpc dial call whose
+// target is a unix socket or matches one of the allowed host patterns.
+func (r *grpcTransportSecurity) isAllowedDial(n ast.Node, ctx *gosec.Context) bool {
+	dial := enclosingGRPCDial(n, ctx)
+	if dial == nil {
+		return false
+	}
+
+	targetIdx := 0
+	if _, ok := gosec.MatchCallByPackage(dial, ctx, grpcPkg, "DialContext"); ok {
+		targetIdx = 1
+	}
+	if targetIdx >= len(dial.Args) {
+		return false
+	}
+
+	targets := grpcTargetValues(dial.Args[targetIdx], ctx)
+	if len(targets) == 0 {
+		return false
+	}
+	for _, target := range targets {
+		if !r.isAllowedTarget(target) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *grpcTransportSecurity) isAllowedTarget(target string) bool {
+	if strings.HasPrefix(target, "unix:") || strings.HasPrefix(target, "unix-abstract:") {
+		return true
+	}
+
+	// Strip resolver schemes such as dns:/// or passthrough:///.
+	if idx := strings.Index(target, ":///"); idx >= 0 {
+		target = target[idx+len(":///"):]
+	}
+
+	host := target
+	if h, _, err := net.SplitHostPort(target); err == nil {
+		host = h
+	}
+
+	for _, pattern := range r.allowedHosts {
+		if gosec.RegexMatchWithCache(pattern, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// enclosingGRPCDial returns the innermost grpc dial call containing n, if any.
+func enclosingGRPCDial(n ast.Node, ctx *gosec.Context) *ast.CallExpr {
+	if ctx.Root == nil {
+		return nil
+	}
+
+	var dial *ast.CallExpr
+	ast.Inspect(ctx.Root, func(node ast.Node) bool {
+		if node == nil || node.Pos() > n.Pos() || node.End() < n.End() {
+			return false
+		}
+		if node == n {
+			return false
+		}
+		if callExpr, ok := gosec.MatchCallByPackage(node, ctx, grpcPkg, grpcDialFuncs...); ok {
+			dial = callExpr
+		}
+		return true
+	})
+	return dial
+}
+
+// grpcTargetValues resolves the possible constant values of a dial target.
+func grpcTargetValues(expr ast.Expr, ctx *gosec.Context) []string {
+	if tv, ok := ctx.Info.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+		return []string{constant.StringVal(tv.Value)}
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return gosec.GetIdentStringValues(ident)
+	}
+	return nil
+}
+
+// hasGRPCCreds reports whether the server options expr include grpc.Creds,
+// following variables, option slices and appends to them. resolved is false
+// when some of the options cannot be inspected, e.g. parameters or results of
+// other calls.
+func hasGRPCCreds(expr ast.Expr, ctx *gosec.Context, visited map[types.Object]bool) (found, resolved bool) {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.CallExpr:
+		if _, ok := gosec.MatchCallByPackage(e, ctx, grpcPkg, "Creds"); ok {
+			return true, true
+		}
+		if ident, ok := e.Fun.(*ast.Ident); ok && ident.Name == "append" {
+			if _, ok := ctx.Info.Uses[ident].(*types.Builtin); ok {
+				return anyGRPCCreds(e.Args, ctx, visited)
+			}
+		}
+		if fn := calledFunc(e, ctx); fn != nil && fn.Pkg().Path() == grpcPkg {
+			// Other grpc server options
+			return false, true
+		}
+		return false, false
+	case *ast.CompositeLit:
+		return anyGRPCCreds(e.Elts, ctx, visited)
+	case *ast.Ident:
+		obj := ctx.Info.ObjectOf(e)
+		if obj == nil || ctx.Root == nil {
+			return false, false
+		}
+		if visited[obj] {
+			return false, true
+		}
+		visited[obj] = true
+		// Every value assigned to the variable in the file
+		var values []ast.Expr
+		ast.Inspect(ctx.Root, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				if len(node.Lhs) != len(node.Rhs) {
+					return true
+				}
+				for i, lhs := range node.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok && ctx.Info.ObjectOf(id) == obj {
+						values = append(values, node.Rhs[i])
+					}
+				}
+			case *ast.ValueSpec:
+				for i, name := range node.Names {
+					if i < len(node.Values) && ctx.Info.ObjectOf(name) == obj {
+						values = append(values, node.Values[i])
+					}
+				}
+			}
+			return true
+		})
+		if len(values) == 0 {
+			return false, false
+		}
+		return anyGRPCCreds(values, ctx, visited)
+	}
+	return false, false
+}
+
+func anyGRPCCreds(exprs []ast.Expr, ctx *gosec.Context, visited map[types.Object]bool) (found, resolved bool) {
+	resolved = true
+	for _, expr := range exprs {
+		f, r := hasGRPCCreds(expr, ctx, visited)
+		if f {
+			return true, true
+		}
+		resolved = resolved && r
+	}
+	return false, resolved
+}
+
+// isInsecureSkipVerifyConfig reports whether expr is a tls.Config literal,
+// or a variable initialised from one, with InsecureSkipVerify set to true.
+func isInsecureSkipVerifyConfig(expr ast.Expr, ctx *gosec.Context) bool {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+
+	if ident, ok := expr.(*ast.Ident); ok {
+		obj := ctx.Info.ObjectOf(ident)
+		if obj == nil || ctx.Root == nil {
+			return false
+		}
+		// The value the variable is declared or first assigned with
+		var value ast.Expr
+		ast.Inspect(ctx.Root, func(n ast.Node) bool {
+			if value != nil {
+				return false
+			}
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				for i, lhs := range node.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok && i < len(node.Rhs) && ctx.Info.Defs[id] == obj {
+						value = node.Rhs[i]
+					}
+				}
+			case *ast.ValueSpec:
+				for i, name := range node.Names {
+					if i < len(node.Values) && ctx.Info.Defs[name] == obj {
+						value = node.Values[i]
+					}
+				}
+			}
+			return true
+		})
+		return value != nil && isInsecureSkipVerifyConfig(value, ctx)
+	}
+
+	lit := gosec.MatchCompLit(expr, ctx, "crypto/tls.Config")
+	if lit == nil {
+		return false
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); !ok || key.Name != "InsecureSkipVerify" {
+			continue
+		}
+		if tv, ok := ctx.Info.Types[kv.Value]; ok && tv.Value != nil && tv.Value.Kind() == constant.Bool {
+			return constant.BoolVal(tv.Value)
+		}
+	}
+	return false
+}
+
+// NewGRPCTransportSecurity detects gRPC clients and servers that disable or
+// omit transport security.
+func NewGRPCTransportSecurity(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	hostPatterns := []string{
+		`^localhost$`,
+		`^127(\.\d{1,3}){3}$`,
+		`^::1$`,
+	}
+
+	if val, ok := conf[id]; ok {
+		if m, ok := val.(map[string]interface{}); ok {
+			if hosts, ok := m["allowed_hosts"].([]interface{}); ok {
+				hostPatterns = append(hostPatterns, toStringSlice(hosts)...)
+			}
+		}
+	}
+
+	allowedHosts := make([]*regexp.Regexp, 0, len(hostPatterns))
+	for _, p := range hostPatterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			log.Printf("Ignoring invalid %s allowed_hosts pattern %q: %v", id, p, err)
+			continue
+		}
+		allowedHosts = append(allowedHosts, re)
+	}
+
+	return &grpcTransportSecurity{
+		MetaData:     issue.NewMetaData(id, "gRPC transport security is disabled", issue.High, issue.High),
+		allowedHosts: allowedHosts,
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
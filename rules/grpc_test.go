@@ -0,0 +1,26 @@
+package rules
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/securego/gosec/v2"
+)
+
+var _ = Describe("NewGRPCTransportSecurity", func() {
+	It("should skip invalid allowed_hosts patterns", func() {
+		config := gosec.NewConfig()
+		config["G409"] = map[string]interface{}{
+			"allowed_hosts": []interface{}{`([a-z`, `^internal\.example\.com$`},
+		}
+
+		var rule gosec.Rule
+		Expect(func() { rule, _ = NewGRPCTransportSecurity("G409", config) }).ShouldNot(Panic())
+
+		grpcRule, ok := rule.(*grpcTransportSecurity)
+		Expect(ok).To(BeTrue())
+		Expect(grpcRule.isAllowedTarget("internal.example.com:443")).To(BeTrue())
+		Expect(grpcRule.isAllowedTarget("localhost:50051")).To(BeTrue())
+		Expect(grpcRule.isAllowedTarget("api.example.com:443")).To(BeFalse())
+	})
+})
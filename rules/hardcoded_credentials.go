@@ -378,10 +378,13 @@ func (r *credentials) matchCompositeLit(lit *ast.CompositeLit, ctx *gosec.Contex
 	return nil, nil
 }
 
+// defaultCredentialsPattern matches identifiers that look like they hold credentials.
+const defaultCredentialsPattern = `(?i)passwd|pass|password|pwd|secret|token|pw|apiKey|bearer|cred`
+
 // NewHardcodedCredentials attempts to find high entropy string constants being
 // assigned to variables that appear to be related to credentials.
 func NewHardcodedCredentials(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
-	pattern := `(?i)passwd|pass|password|pwd|secret|token|pw|apiKey|bearer|cred`
+	pattern := defaultCredentialsPattern
 	entropyThreshold := 80.0
 	perCharThreshold := 3.0
 	ignoreEntropy := false
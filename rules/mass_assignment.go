@@ -0,0 +1,294 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"regexp"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+)
+
+type massAssignment struct {
+	issue.MetaData
+	pattern *regexp.Regexp
+	sinks   gosec.CallList
+}
+
+type bindableFieldMatch struct {
+	fieldName     string
+	serializedKey string
+	found         bool
+}
+
+// Match inspects a function body for request bodies decoded into a struct
+// value which is later handed to an ORM save/update call unchanged.
+func (r *massAssignment) Match(n ast.Node, ctx *gosec.Context) (*issue.Issue, error) {
+	fn, ok := n.(*ast.FuncDecl)
+	if !ok || fn.Body == nil || ctx.Info == nil {
+		return nil, nil
+	}
+
+	decoders := make(map[types.Object]bool)
+	bodies := make(map[types.Object]bool)
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		assign, ok := node.(*ast.AssignStmt)
+		if !ok || len(assign.Rhs) != 1 {
+			return true
+		}
+		call, ok := assign.Rhs[0].(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 || !isRequestBody(call.Args[0], ctx) {
+			return true
+		}
+		obj := identObject(assign.Lhs[0], ctx)
+		if obj == nil {
+			return true
+		}
+		switch {
+		case callMatchesPackageFunction(call, ctx, "encoding/json", "NewDecoder"):
+			decoders[obj] = true
+		case callMatchesPackageFunction(call, ctx, "io", "ReadAll"),
+			callMatchesPackageFunction(call, ctx, "io/ioutil", "ReadAll"):
+			bodies[obj] = true
+		}
+		return true
+	})
+
+	bound := make(map[types.Object]bool)
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if target := r.decodedTarget(call, ctx, decoders, bodies); target != nil {
+			bound[target] = true
+		}
+		return true
+	})
+	if len(bound) == 0 {
+		return nil, nil
+	}
+
+	var found *issue.Issue
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		call, ok := node.(*ast.CallExpr)
+		if !ok || !r.isPersistenceCall(call, ctx) {
+			return true
+		}
+		for _, arg := range call.Args {
+			obj := identObject(unwrapAddr(arg), ctx)
+			if obj == nil || !bound[obj] {
+				continue
+			}
+			match := r.findBindableField(obj.Type(), make(map[types.Type]struct{}))
+			if !match.found {
+				continue
+			}
+			msg := fmt.Sprintf("Request body is decoded into %q and persisted while sensitive field %q (JSON key %q) is bindable; tag it `json:\"-\"` or decode into a separate input type",
+				obj.Name(), match.fieldName, match.serializedKey)
+			found = ctx.NewIssue(call, r.ID(), msg, r.Severity, r.Confidence)
+			return false
+		}
+		return true
+	})
+
+	return found, nil
+}
+
+// decodedTarget returns the variable populated by a JSON decode of the
+// request body, or nil if call is not such a decode.
+func (r *massAssignment) decodedTarget(call *ast.CallExpr, ctx *gosec.Context, decoders, bodies map[types.Object]bool) types.Object {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel == nil {
+		return nil
+	}
+
+	switch {
+	case selector.Sel.Name == "Decode" && len(call.Args) == 1:
+		if !isNamedTypeInPackage(ctx.Info.TypeOf(selector.X), "encoding/json", "Decoder") {
+			return nil
+		}
+		// json.NewDecoder(r.Body).Decode(&v)
+		if inner, ok := selector.X.(*ast.CallExpr); ok {
+			if len(inner.Args) == 0 || !isRequestBody(inner.Args[0], ctx) {
+				return nil
+			}
+		} else if obj := identObject(selector.X, ctx); obj == nil || !decoders[obj] {
+			return nil
+		}
+		return identObject(unwrapAddr(call.Args[0]), ctx)
+	case callMatchesPackageFunction(call, ctx, "encoding/json", "Unmarshal") && len(call.Args) == 2:
+		if obj := identObject(call.Args[0], ctx); obj == nil || !bodies[obj] {
+			return nil
+		}
+		return identObject(unwrapAddr(call.Args[1]), ctx)
+	}
+	return nil
+}
+
+// isPersistenceCall checks whether call is one of the configured ORM
+// create/save/update entry points.
+func (r *massAssignment) isPersistenceCall(call *ast.CallExpr, ctx *gosec.Context) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel == nil {
+		return false
+	}
+	fn, ok := ctx.Info.Uses[selector.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return r.sinks.Contains(fn.Pkg().Path(), fn.Name())
+	}
+
+	recv := sig.Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	named, ok := recv.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return r.sinks.Contains(named.Obj().Pkg().Path()+"."+named.Obj().Name(), fn.Name())
+}
+
+// findBindableField walks the struct behind typ, including embedded structs,
+// looking for a sensitive field that the JSON decoder is allowed to populate.
+func (r *massAssignment) findBindableField(typ types.Type, visited map[types.Type]struct{}) bindableFieldMatch {
+	if _, seen := visited[typ]; seen {
+		return bindableFieldMatch{}
+	}
+	visited[typ] = struct{}{}
+
+	switch t := typ.(type) {
+	case *types.Named:
+		return r.findBindableField(t.Underlying(), visited)
+	case *types.Pointer:
+		return r.findBindableField(t.Elem(), visited)
+	case *types.Slice:
+		return r.findBindableField(t.Elem(), visited)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			field := t.Field(i)
+			if !field.Exported() {
+				continue
+			}
+			effectiveKey, omitted := serializedNameFromTag(field.Name(), t.Tag(i), "json")
+			if omitted {
+				continue
+			}
+			if field.Embedded() {
+				if match := r.findBindableField(field.Type(), visited); match.found {
+					return match
+				}
+				continue
+			}
+			if gosec.RegexMatchWithCache(r.pattern, field.Name()) || gosec.RegexMatchWithCache(r.pattern, effectiveKey) {
+				return bindableFieldMatch{fieldName: field.Name(), serializedKey: effectiveKey, found: true}
+			}
+		}
+	}
+	return bindableFieldMatch{}
+}
+
+// isRequestBody reports whether expr is the Body of an *http.Request, possibly
+// wrapped by a reader constructor such as http.MaxBytesReader or io.LimitReader.
+func isRequestBody(expr ast.Expr, ctx *gosec.Context) bool {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return isRequestBody(e.X, ctx)
+	case *ast.SelectorExpr:
+		return e.Sel != nil && e.Sel.Name == "Body" && isNamedTypeInPackage(ctx.Info.TypeOf(e.X), "net/http", "Request")
+	case *ast.CallExpr:
+		for _, arg := range e.Args {
+			if isRequestBody(arg, ctx) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func unwrapAddr(expr ast.Expr) ast.Expr {
+	for {
+		switch e := expr.(type) {
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.UnaryExpr:
+			expr = e.X
+		default:
+			return expr
+		}
+	}
+}
+
+func identObject(expr ast.Expr, ctx *gosec.Context) types.Object {
+	ident, ok := expr.(*ast.Ident)
+	if !ok || ident.Name == "_" {
+		return nil
+	}
+	return ctx.Info.ObjectOf(ident)
+}
+
+// defaultBindableFieldPattern matches field names and JSON keys with a
+// privilege-bearing word as a whole segment, split on '_', '-' or a camelCase
+// boundary, so that IsAdmin and owner_id match but Controller, Payroll,
+// Badminton and Ownership do not.
+const defaultBindableFieldPattern = `(?:^|[_\-])(?i:role|admin|owner|balance|permission|privilege|superuser)s?(?:$|[_\-A-Z0-9])` +
+	`|[a-z0-9](?:Role|Admin|Owner|Balance|Permission|Privilege|Superuser)s?(?:$|[_\-A-Z0-9])`
+
+// NewMassAssignment detects HTTP request bodies decoded straight into
+// persistence models that expose privilege-bearing fields.
+func NewMassAssignment(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	patternStr := defaultBindableFieldPattern
+
+	sinks := gosec.NewCallList()
+	for _, pkg := range []string{"gorm.io/gorm", "github.com/jinzhu/gorm"} {
+		sinks.AddAll(pkg+".DB", "Create", "CreateInBatches", "Save", "Updates", "FirstOrCreate")
+	}
+	sinks.AddAll("github.com/jmoiron/sqlx", "NamedExec", "NamedExecContext", "NamedQuery", "NamedQueryContext")
+	for _, typ := range []string{"DB", "Tx", "Conn"} {
+		sinks.AddAll("github.com/jmoiron/sqlx."+typ, "NamedExec", "NamedExecContext", "NamedQuery", "NamedQueryContext")
+	}
+
+	if val, ok := conf[id]; ok {
+		if m, ok := val.(map[string]interface{}); ok {
+			if p, ok := m["pattern"].(string); ok && p != "" {
+				patternStr = p
+			}
+			if configured, ok := m["sinks"].(map[string]interface{}); ok {
+				for selector, funcs := range configured {
+					if funcs, ok := funcs.([]interface{}); ok {
+						sinks.AddAll(selector, toStringSlice(funcs)...)
+					}
+				}
+			}
+		}
+	}
+
+	return &massAssignment{
+		pattern:  regexp.MustCompile(patternStr),
+		sinks:    sinks,
+		MetaData: issue.NewMetaData(id, "Request body decoded into a persisted struct with bindable sensitive fields (mass assignment)", issue.Medium, issue.Medium),
+	}, []ast.Node{(*ast.FuncDecl)(nil)}
+}
@@ -0,0 +1,129 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/securego/gosec/v2"
+)
+
+// isLocalPathGuarded reports whether expr is validated by filepath.IsLocal
+// (or fs.ValidPath) before node is reached, either because node sits in the
+// body of `if filepath.IsLocal(expr) { ... }` or because an earlier statement
+// in an enclosing block is `if !filepath.IsLocal(expr) { return }`.
+func isLocalPathGuarded(node ast.Node, expr ast.Expr, ctx *gosec.Context) bool {
+	if ctx.Root == nil || expr == nil {
+		return false
+	}
+
+	var path []ast.Node
+	ast.Inspect(ctx.Root, func(n ast.Node) bool {
+		if n == nil || n.Pos() > node.Pos() || n.End() < node.End() {
+			return false
+		}
+		path = append(path, n)
+		return n != node
+	})
+
+	for i, n := range path {
+		switch stmt := n.(type) {
+		case *ast.IfStmt:
+			if i+1 < len(path) && path[i+1] == stmt.Body && isLocalPathCheck(stmt.Cond, expr, ctx, false) {
+				return true
+			}
+		case *ast.BlockStmt:
+			for _, s := range stmt.List {
+				if s.End() > node.Pos() {
+					break
+				}
+				ifStmt, ok := s.(*ast.IfStmt)
+				if !ok || ifStmt.Else != nil || !endsWithExit(ifStmt.Body) {
+					continue
+				}
+				if isLocalPathCheck(ifStmt.Cond, expr, ctx, true) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isLocalPathCheck matches filepath.IsLocal(expr) or, when negated is set,
+// !filepath.IsLocal(expr). Conjunctions are searched for a matching operand.
+func isLocalPathCheck(cond ast.Expr, expr ast.Expr, ctx *gosec.Context, negated bool) bool {
+	switch c := cond.(type) {
+	case *ast.ParenExpr:
+		return isLocalPathCheck(c.X, expr, ctx, negated)
+	case *ast.UnaryExpr:
+		if c.Op == token.NOT && negated {
+			return isLocalPathCheck(c.X, expr, ctx, false)
+		}
+	case *ast.BinaryExpr:
+		// if IsLocal(p) && ok { ... } guards p; if !IsLocal(p) || bad { return } does too.
+		if (!negated && c.Op == token.LAND) || (negated && c.Op == token.LOR) {
+			return isLocalPathCheck(c.X, expr, ctx, negated) || isLocalPathCheck(c.Y, expr, ctx, negated)
+		}
+	case *ast.CallExpr:
+		if negated || len(c.Args) != 1 {
+			return false
+		}
+		_, isLocal := gosec.MatchCallByPackage(c, ctx, "path/filepath", "IsLocal")
+		_, validPath := gosec.MatchCallByPackage(c, ctx, "io/fs", "ValidPath")
+		return (isLocal || validPath) && sameReference(c.Args[0], expr, ctx)
+	}
+	return false
+}
+
+// sameReference reports whether a and b refer to the same variable or field.
+func sameReference(a, b ast.Expr, ctx *gosec.Context) bool {
+	switch x := a.(type) {
+	case *ast.ParenExpr:
+		return sameReference(x.X, b, ctx)
+	case *ast.Ident:
+		y, ok := b.(*ast.Ident)
+		if !ok {
+			return false
+		}
+		obj := ctx.Info.ObjectOf(x)
+		return obj != nil && obj == ctx.Info.ObjectOf(y)
+	case *ast.SelectorExpr:
+		y, ok := b.(*ast.SelectorExpr)
+		return ok && x.Sel.Name == y.Sel.Name && sameReference(x.X, y.X, ctx)
+	}
+	return false
+}
+
+// endsWithExit reports whether the block ends by leaving the current flow.
+func endsWithExit(body *ast.BlockStmt) bool {
+	if body == nil || len(body.List) == 0 {
+		return false
+	}
+	switch last := body.List[len(body.List)-1].(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.BranchStmt:
+		return last.Tok == token.CONTINUE || last.Tok == token.BREAK || last.Tok == token.GOTO
+	case *ast.ExprStmt:
+		if call, ok := last.X.(*ast.CallExpr); ok {
+			if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == "panic" {
+				return true
+			}
+		}
+	}
+	return false
+}
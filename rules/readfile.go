@@ -140,6 +140,38 @@ func (r *readfile) isSafeJoin(call *ast.CallExpr, c *gosec.Context) bool {
 	return hasBaseDir && hasCleanArg
 }
 
+// isLocalGuarded checks if the path argument, a variable it is built from, or
+// an argument of the Join() that produced it is validated by filepath.IsLocal
+func (r *readfile) isLocalGuarded(call *ast.CallExpr, pathArg ast.Expr, c *gosec.Context) bool {
+	guarded := false
+	var inspect func(expr ast.Node)
+	inspect = func(expr ast.Node) {
+		ast.Inspect(expr, func(n ast.Node) bool {
+			if guarded {
+				return false
+			}
+			switch e := n.(type) {
+			case *ast.SelectorExpr:
+				guarded = isLocalPathGuarded(call, e, c)
+				return false
+			case *ast.Ident:
+				if isLocalPathGuarded(call, e, c) {
+					guarded = true
+					return false
+				}
+				if v, ok := c.Info.ObjectOf(e).(*types.Var); ok {
+					if joinCall, ok := r.joinedVar[v]; ok {
+						inspect(joinCall)
+					}
+				}
+			}
+			return true
+		})
+	}
+	inspect(pathArg)
+	return guarded
+}
+
 func (r *readfile) Match(n ast.Node, c *gosec.Context) (*issue.Issue, error) {
 	// Track assignments from Clean() or Join()
 	if assign, ok := n.(*ast.AssignStmt); ok {
@@ -154,6 +186,11 @@ func (r *readfile) Match(n ast.Node, c *gosec.Context) (*issue.Issue, error) {
 		}
 		pathArg := readCall.Args[0]
 
+		// Path validated with filepath.IsLocal before use → safe
+		if r.isLocalGuarded(readCall, pathArg, c) {
+			return nil, nil
+		}
+
 		// Direct Clean() call as argument → safe
 		if cleanCall, ok := pathArg.(*ast.CallExpr); ok {
 			if r.clean.ContainsPkgCallExpr(cleanCall, c, false) != nil {
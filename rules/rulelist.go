@@ -78,6 +78,9 @@ func Generate(trackSuppressions bool, filters ...RuleFilter) RuleList {
 		{"G114", "Use of net/http serve function that has no support for setting timeouts", NewHTTPServeWithoutTimeouts},
 		{"G116", "Detect Trojan Source attacks using bidirectional Unicode characters", NewTrojanSource},
 		{"G117", "Potential exposure of secrets via JSON/YAML/XML/TOML marshaling", NewSecretSerialization},
+		{"G125", "Mass assignment of request bodies into persisted structs", NewMassAssignment},
+		{"G126", "Sensitive data written to logs", NewSensitiveLogging},
+		{"G130", "Secrets passed to subprocesses through the environment", NewSubprocEnv},
 
 		// injection
 		{"G201", "SQL query construction using format string", NewSQLStrFormat},
@@ -101,6 +104,7 @@ func Generate(trackSuppressions bool, filters ...RuleFilter) RuleList {
 		{"G404", "Insecure random number source (rand)", NewWeakRandCheck},
 		{"G405", "Detect the usage of DES or RC4", NewUsesWeakCryptographyEncryption},
 		{"G406", "Detect the usage of deprecated MD4 or RIPEMD160", NewUsesWeakDeprecatedCryptographyHash},
+		{"G409", "Detect gRPC clients and servers without transport security", NewGRPCTransportSecurity},
 
 		// blocklist
 		{"G501", "Import blocklist: crypto/md5", NewBlocklistedImportMD5},
@@ -115,6 +115,18 @@ var _ = Describe("gosec rules", func() {
 			runner("G117", testutils.SampleCodeG117)
 		})
 
+		It("should detect mass assignment of request bodies into persisted structs", func() {
+			runner("G125", testutils.SampleCodeG125)
+		})
+
+		It("should detect sensitive data written to logs", func() {
+			runner("G126", testutils.SampleCodeG126)
+		})
+
+		It("should audit secrets passed to subprocesses through the environment", func() {
+			runner("G130", testutils.SampleCodeG130)
+		})
+
 		It("should detect sql injection via format strings", func() {
 			runner("G201", testutils.SampleCodeG201)
 		})
@@ -191,6 +203,10 @@ var _ = Describe("gosec rules", func() {
 			runner("G406", testutils.SampleCodeG406b)
 		})
 
+		It("should detect gRPC clients and servers without transport security", func() {
+			runner("G409", testutils.SampleCodeG409)
+		})
+
 		It("should detect blocklisted imports - MD5", func() {
 			runner("G501", testutils.SampleCodeG501)
 		})
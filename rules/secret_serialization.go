@@ -570,8 +570,11 @@ func serializedNameFromTag(defaultName, tag, tagKey string) (name string, omitte
 	return name, false
 }
 
+// defaultSecretFieldPattern matches struct field names and serialized keys that hold secrets.
+const defaultSecretFieldPattern = `(?i)\b((?:api|access|auth|bearer|client|oauth|private|refresh|session|jwt)[_-]?(?:key|secret|token)s?|password|passwd|pwd|pass|secret|cred|jwt)\b`
+
 func NewSecretSerialization(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
-	patternStr := `(?i)\b((?:api|access|auth|bearer|client|oauth|private|refresh|session|jwt)[_-]?(?:key|secret|token)s?|password|passwd|pwd|pass|secret|cred|jwt)\b`
+	patternStr := defaultSecretFieldPattern
 
 	if val, ok := conf[id]; ok {
 		if m, ok := val.(map[string]interface{}); ok {
@@ -0,0 +1,269 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"net/textproto"
+	"regexp"
+	"strings"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+)
+
+type sensitiveLogging struct {
+	issue.MetaData
+	pattern       *regexp.Regexp
+	keyPattern    *regexp.Regexp
+	serialization *secretSerialization
+}
+
+// loggerPackages lists packages whose functions and methods write log records
+// or build structured log attributes.
+var loggerPackages = map[string]bool{
+	"log":                        true,
+	"log/slog":                   true,
+	"go.uber.org/zap":            true,
+	"github.com/rs/zerolog":      true,
+	"github.com/rs/zerolog/log":  true,
+	"github.com/sirupsen/logrus": true,
+}
+
+// sensitiveHeaders are request headers that carry credentials.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"X-Api-Key":           true,
+	"X-Auth-Token":        true,
+}
+
+// logSafeMethods are methods which let a type control its own log or text
+// representation; struct dumps of such types are not reported.
+var logSafeMethods = []string{"LogValue", "MarshalLogObject", "MarshalZerologObject", "String", "Format", "MarshalJSON"}
+
+var logAttrKey = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// defaultLogKeyPattern matches attribute keys whose last segment, split on
+// '_', '.', '-' or a camelCase boundary, names a secret. Keys such as
+// "password_policy" or "passthrough" do not match.
+const defaultLogKeyPattern = `(?:^|[_.\-])(?i:passwd|password|pwd|pw|pass|passphrase|secret|token|api[_\-]?key|bearer|creds?|credentials?)s?$` +
+	`|[a-z0-9](?:Passwd|Password|Pwd|Pass|Passphrase|Secret|Token|APIKey|ApiKey|Bearer|Creds?|Credentials?)s?$`
+
+func (r *sensitiveLogging) Match(n ast.Node, ctx *gosec.Context) (*issue.Issue, error) {
+	callExpr, ok := n.(*ast.CallExpr)
+	if !ok || ctx.Info == nil || !isLoggerCall(callExpr, ctx) {
+		return nil, nil
+	}
+
+	dumpVerb := false
+	for i, arg := range callExpr.Args {
+		if key, ok := stringConstant(arg, ctx); ok {
+			if strings.Contains(key, "%v") || strings.Contains(key, "%+v") || strings.Contains(key, "%#v") {
+				dumpVerb = true
+			}
+			// Key/value style attributes: slog.Info(msg, "password", pw), zap.String("token", t)
+			if i+1 < len(callExpr.Args) && logAttrKey.MatchString(key) && gosec.RegexMatchWithCache(r.keyPattern, key) {
+				next := callExpr.Args[i+1]
+				if _, isConst := stringConstant(next, ctx); !isConst && isSecretCandidateType(ctx.Info.TypeOf(next)) {
+					return r.newIssue(callExpr, ctx, fmt.Sprintf("Log attribute %q appears to hold a secret", key)), nil
+				}
+			}
+			continue
+		}
+
+		if msg := r.sensitiveValue(arg, ctx, dumpVerb); msg != "" {
+			return r.newIssue(callExpr, ctx, msg), nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *sensitiveLogging) newIssue(n ast.Node, ctx *gosec.Context, msg string) *issue.Issue {
+	return ctx.NewIssue(n, r.ID(), msg, r.Severity, r.Confidence)
+}
+
+// sensitiveValue returns a description of the secret written by expr, or an
+// empty string if expr does not appear to carry sensitive data. Calls other
+// than type conversions are not descended into, since they usually transform
+// the value (hashing, masking, len, ...).
+func (r *sensitiveLogging) sensitiveValue(expr ast.Expr, ctx *gosec.Context, dumpVerb bool) string {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return r.sensitiveValue(e.X, ctx, dumpVerb)
+	case *ast.BinaryExpr:
+		if msg := r.sensitiveValue(e.X, ctx, dumpVerb); msg != "" {
+			return msg
+		}
+		return r.sensitiveValue(e.Y, ctx, dumpVerb)
+	case *ast.CompositeLit:
+		// e.g. logrus.Fields{"password": pw}
+		for _, elt := range e.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			if key, ok := stringConstant(kv.Key, ctx); ok && gosec.RegexMatchWithCache(r.keyPattern, key) {
+				if _, isConst := stringConstant(kv.Value, ctx); !isConst && isSecretCandidateType(ctx.Info.TypeOf(kv.Value)) {
+					return fmt.Sprintf("Log attribute %q appears to hold a secret", key)
+				}
+			}
+			if msg := r.sensitiveValue(kv.Value, ctx, dumpVerb); msg != "" {
+				return msg
+			}
+		}
+		return ""
+	case *ast.CallExpr:
+		if tv, ok := ctx.Info.Types[e.Fun]; ok && tv.IsType() && len(e.Args) == 1 {
+			return r.sensitiveValue(e.Args[0], ctx, dumpVerb)
+		}
+		if header, ok := headerLookup(e, ctx); ok {
+			return fmt.Sprintf("Value of the %s request header is written to a log", header)
+		}
+		return ""
+	case *ast.IndexExpr:
+		if isNamedTypeInPackage(ctx.Info.TypeOf(e.X), "net/http", "Header") {
+			if key, ok := stringConstant(e.Index, ctx); ok && sensitiveHeaders[textproto.CanonicalMIMEHeaderKey(key)] {
+				return fmt.Sprintf("Value of the %s request header is written to a log", textproto.CanonicalMIMEHeaderKey(key))
+			}
+		}
+		return ""
+	}
+
+	typ := ctx.Info.TypeOf(expr)
+	if typ == nil {
+		return ""
+	}
+	if _, isConst := stringConstant(expr, ctx); isConst {
+		return ""
+	}
+
+	if name := exprName(expr); name != "" && isSecretCandidateType(typ) && gosec.RegexMatchWithCache(r.pattern, name) {
+		return fmt.Sprintf("Secret-looking value %q is written to a log", name)
+	}
+
+	if isNamedTypeInPackage(typ, "net/http", "Header") {
+		return "HTTP headers, including Authorization, are written to a log"
+	}
+
+	if dumpVerb && isNamedTypeInPackage(typ, "net/http", "Request") {
+		return "HTTP request, including its Authorization header, is dumped to a log"
+	}
+
+	if isDumpableStruct(typ) && !implementsAnyMethod(typ, logSafeMethods) {
+		if match := r.serialization.findSensitiveFieldForType(typ, "json"); match.found {
+			return fmt.Sprintf("Struct with secret field %q is dumped to a log", match.fieldName)
+		}
+	}
+	return ""
+}
+
+// isLoggerCall reports whether callExpr invokes a function or method from one
+// of the supported logging packages.
+func isLoggerCall(callExpr *ast.CallExpr, ctx *gosec.Context) bool {
+	selector, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel == nil {
+		return false
+	}
+	fn, ok := ctx.Info.Uses[selector.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return false
+	}
+	return loggerPackages[fn.Pkg().Path()]
+}
+
+// headerLookup matches h.Get("Authorization") and h.Values("Authorization") on
+// an http.Header, returning the canonical header name.
+func headerLookup(callExpr *ast.CallExpr, ctx *gosec.Context) (string, bool) {
+	selector, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || (selector.Sel.Name != "Get" && selector.Sel.Name != "Values") || len(callExpr.Args) != 1 {
+		return "", false
+	}
+	if !isNamedTypeInPackage(ctx.Info.TypeOf(selector.X), "net/http", "Header") {
+		return "", false
+	}
+	key, ok := stringConstant(callExpr.Args[0], ctx)
+	if !ok {
+		return "", false
+	}
+	key = textproto.CanonicalMIMEHeaderKey(key)
+	return key, sensitiveHeaders[key]
+}
+
+func stringConstant(expr ast.Expr, ctx *gosec.Context) (string, bool) {
+	tv, ok := ctx.Info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+func exprName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.StarExpr:
+		return exprName(e.X)
+	}
+	return ""
+}
+
+func isDumpableStruct(typ types.Type) bool {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	_, ok := typ.Underlying().(*types.Struct)
+	return ok
+}
+
+func implementsAnyMethod(typ types.Type, methods []string) bool {
+	for _, method := range methods {
+		if typeImplementsMarshaler(typ, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewSensitiveLogging detects secrets, credential headers and struct dumps
+// containing secrets written through standard and structured loggers.
+func NewSensitiveLogging(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	patternStr := defaultCredentialsPattern
+	keyPatternStr := defaultLogKeyPattern
+
+	if val, ok := conf[id]; ok {
+		if m, ok := val.(map[string]interface{}); ok {
+			if p, ok := m["pattern"].(string); ok && p != "" {
+				patternStr = p
+			}
+			if p, ok := m["key_pattern"].(string); ok && p != "" {
+				keyPatternStr = p
+			}
+		}
+	}
+
+	return &sensitiveLogging{
+		pattern:       regexp.MustCompile(patternStr),
+		keyPattern:    regexp.MustCompile(keyPatternStr),
+		serialization: &secretSerialization{pattern: regexp.MustCompile(defaultSecretFieldPattern)},
+		MetaData:      issue.NewMetaData(id, "Sensitive data written to logs", issue.Medium, issue.Medium),
+	}, []ast.Node{(*ast.CallExpr)(nil)}
+}
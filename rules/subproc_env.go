@@ -0,0 +1,333 @@
+// (c) Copyright gosec's authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+
+	"github.com/securego/gosec/v2"
+	"github.com/securego/gosec/v2/issue"
+)
+
+// defaultSecretEnvPattern matches environment variable and struct field names
+// that look like they hold credentials, e.g. DB_PASSWORD or APIKey.
+const defaultSecretEnvPattern = `(?i)passw(or)?d|secret|token|api_?key|private_?key|access_?key|credential`
+
+type subprocEnv struct {
+	issue.MetaData
+	pattern *regexp.Regexp
+	calls   gosec.CallList
+
+	// secretEnv is the first secret-looking variable set with os.Setenv in
+	// the package, resolved on first use.
+	secretEnv string
+	scanned   bool
+}
+
+func (r *subprocEnv) Match(n ast.Node, ctx *gosec.Context) (*issue.Issue, error) {
+	if enabled, err := ctx.Config.IsGlobalEnabled(gosec.Audit); err != nil || !enabled || ctx.Info == nil {
+		return nil, nil
+	}
+
+	switch node := n.(type) {
+	case *ast.CallExpr:
+		call := r.calls.ContainsPkgCallExpr(node, ctx, false)
+		if call == nil {
+			return nil, nil
+		}
+		// syscall.Exec(argv0, argv, envv) takes the environment explicitly
+		if fn := calledFunc(call, ctx); fn != nil && fn.Pkg().Path() == "syscall" {
+			if len(call.Args) == 3 {
+				return r.checkEnv(call.Args[2], call, ctx), nil
+			}
+			return nil, nil
+		}
+		return r.checkInherited(call, ctx), nil
+	case *ast.CompositeLit:
+		typ := ctx.Info.TypeOf(node)
+		isCmd := isNamedTypeInPackage(typ, "os/exec", "Cmd")
+		if !isCmd && !isNamedTypeInPackage(typ, "os", "ProcAttr") && !isNamedTypeInPackage(typ, "syscall", "ProcAttr") {
+			return nil, nil
+		}
+		for _, elt := range node.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Env" {
+					return r.checkEnv(kv.Value, node, ctx), nil
+				}
+			}
+		}
+		if isCmd {
+			return r.checkInherited(node, ctx), nil
+		}
+	case *ast.AssignStmt:
+		if len(node.Lhs) != len(node.Rhs) {
+			return nil, nil
+		}
+		for i, lhs := range node.Lhs {
+			if selector, ok := lhs.(*ast.SelectorExpr); ok && selector.Sel.Name == "Env" && isNamedTypeInPackage(ctx.Info.TypeOf(selector.X), "os/exec", "Cmd") {
+				return r.checkEnv(node.Rhs[i], node, ctx), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// checkInherited reports a command whose Env is left nil, so that the child
+// inherits every variable of the process, while a secret is set with os.Setenv.
+func (r *subprocEnv) checkInherited(cmd ast.Expr, ctx *gosec.Context) *issue.Issue {
+	name := r.processSecret(ctx)
+	if name == "" || envConfigured(cmd, ctx) {
+		return nil
+	}
+	return ctx.NewIssue(cmd, r.ID(), fmt.Sprintf("Subprocess inherits the process environment, which holds the secret %s set by os.Setenv", name), r.Severity, issue.Low)
+}
+
+// checkEnv reports an environment built from os.Environ() while a secret is set
+// with os.Setenv, or built with secret fields of a struct.
+func (r *subprocEnv) checkEnv(env ast.Expr, n ast.Node, ctx *gosec.Context) *issue.Issue {
+	if field := r.secretField(env, ctx); field != "" {
+		return ctx.NewIssue(n, r.ID(), fmt.Sprintf("Secret field %q is passed in the environment of a subprocess", field), r.Severity, r.Confidence)
+	}
+	if inheritsEnviron(env, ctx) {
+		if name := r.processSecret(ctx); name != "" {
+			return ctx.NewIssue(n, r.ID(), fmt.Sprintf("Subprocess environment built from os.Environ() includes the secret %s set by os.Setenv", name), r.Severity, r.Confidence)
+		}
+	}
+	return nil
+}
+
+// processSecret returns the name of a secret-looking variable set with
+// os.Setenv anywhere in the package, or an empty string.
+func (r *subprocEnv) processSecret(ctx *gosec.Context) string {
+	if r.scanned {
+		return r.secretEnv
+	}
+	r.scanned = true
+	for _, file := range ctx.PkgFiles {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if r.secretEnv != "" {
+				return false
+			}
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 2 {
+				return true
+			}
+			fn := calledFunc(call, ctx)
+			if fn == nil || fn.Name() != "Setenv" || (fn.Pkg().Path() != "os" && fn.Pkg().Path() != "syscall") {
+				return true
+			}
+			if key, ok := stringConstant(call.Args[0], ctx); ok && gosec.RegexMatchWithCache(r.pattern, key) {
+				r.secretEnv = key
+			}
+			return true
+		})
+	}
+	return r.secretEnv
+}
+
+// secretField returns the name of a secret-looking struct field read while
+// building env, or an empty string.
+func (r *subprocEnv) secretField(env ast.Expr, ctx *gosec.Context) string {
+	var field string
+	ast.Inspect(env, func(n ast.Node) bool {
+		if field != "" {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.SelectorExpr:
+			selection, ok := ctx.Info.Selections[node]
+			if ok && selection.Kind() == types.FieldVal && isSecretCandidateType(selection.Type()) && gosec.RegexMatchWithCache(r.pattern, node.Sel.Name) {
+				field = node.Sel.Name
+			}
+		}
+		return true
+	})
+	return field
+}
+
+// inheritsEnviron reports whether env is os.Environ(), cmd.Environ() or an
+// append to either.
+func inheritsEnviron(env ast.Expr, ctx *gosec.Context) bool {
+	call, ok := ast.Unparen(env).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == "append" && len(call.Args) > 0 {
+		if _, isBuiltin := ctx.Info.Uses[ident].(*types.Builtin); isBuiltin {
+			return inheritsEnviron(call.Args[0], ctx)
+		}
+	}
+	fn := calledFunc(call, ctx)
+	if fn == nil || fn.Name() != "Environ" {
+		return false
+	}
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		return isNamedTypeInPackage(recv.Type(), "os/exec", "Cmd")
+	}
+	return fn.Pkg().Path() == "os"
+}
+
+// envConfigured reports whether the command built by cmd has its Env set in
+// the enclosing function, or leaves the function where it may be set by others.
+func envConfigured(cmd ast.Expr, ctx *gosec.Context) bool {
+	body := enclosingBody(cmd.Pos(), ctx)
+	if body == nil {
+		return true
+	}
+
+	var owner types.Object
+	escapes := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, rhs := range node.Rhs {
+				if i < len(node.Lhs) && wraps(rhs, cmd) {
+					if ident, ok := node.Lhs[i].(*ast.Ident); ok {
+						owner = ctx.Info.ObjectOf(ident)
+					} else {
+						// Stored in a field or element, configured elsewhere
+						escapes = true
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for i, value := range node.Values {
+				if i < len(node.Names) && wraps(value, cmd) {
+					owner = ctx.Info.ObjectOf(node.Names[i])
+				}
+			}
+		case *ast.ReturnStmt:
+			for _, result := range node.Results {
+				if wraps(result, cmd) {
+					escapes = true
+				}
+			}
+		}
+		return true
+	})
+	if escapes {
+		return true
+	}
+	if owner == nil {
+		return false
+	}
+
+	configured := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range node.Lhs {
+				if selector, ok := lhs.(*ast.SelectorExpr); ok && selector.Sel.Name == "Env" && usesObject(selector.X, owner, ctx) {
+					configured = true
+				}
+			}
+		case *ast.ReturnStmt:
+			for _, result := range node.Results {
+				if usesObject(result, owner, ctx) {
+					configured = true
+				}
+			}
+		}
+		return !configured
+	})
+	return configured
+}
+
+// enclosingBody returns the body of the innermost function enclosing pos.
+func enclosingBody(pos token.Pos, ctx *gosec.Context) *ast.BlockStmt {
+	if ctx.Root == nil {
+		return nil
+	}
+	var enclosing *ast.BlockStmt
+	ast.Inspect(ctx.Root, func(n ast.Node) bool {
+		var body *ast.BlockStmt
+		switch f := n.(type) {
+		case *ast.FuncDecl:
+			body = f.Body
+		case *ast.FuncLit:
+			body = f.Body
+		}
+		if body != nil && body.Pos() <= pos && pos < body.End() {
+			enclosing = body
+		}
+		return true
+	})
+	return enclosing
+}
+
+// wraps reports whether expr is node, possibly parenthesized or with its
+// address taken.
+func wraps(expr ast.Expr, node ast.Expr) bool {
+	expr = ast.Unparen(expr)
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = ast.Unparen(unary.X)
+	}
+	return expr == node
+}
+
+func usesObject(expr ast.Expr, obj types.Object, ctx *gosec.Context) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && ctx.Info.ObjectOf(ident) == obj
+}
+
+// calledFunc returns the package function or method called by call.
+func calledFunc(call *ast.CallExpr, ctx *gosec.Context) *types.Func {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+	fn, ok := ctx.Info.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return nil
+	}
+	return fn
+}
+
+// NewSubprocEnv audits subprocesses which receive secrets through their
+// environment: commands inheriting the environment of a process that sets
+// secrets with os.Setenv, and environments built from secret struct fields.
+func NewSubprocEnv(id string, conf gosec.Config) (gosec.Rule, []ast.Node) {
+	patternStr := defaultSecretEnvPattern
+
+	if val, ok := conf[id]; ok {
+		if m, ok := val.(map[string]interface{}); ok {
+			if p, ok := m["pattern"].(string); ok && p != "" {
+				patternStr = p
+			}
+		}
+	}
+
+	calls := gosec.NewCallList()
+	calls.AddAll("os/exec", "Command", "CommandContext")
+	calls.AddAll("golang.org/x/sys/execabs", "Command", "CommandContext")
+	calls.Add("syscall", "Exec")
+
+	return &subprocEnv{
+		pattern:  regexp.MustCompile(patternStr),
+		calls:    calls,
+		MetaData: issue.NewMetaData(id, "Secrets passed to subprocesses through the environment", issue.Medium, issue.Medium),
+	}, []ast.Node{(*ast.CallExpr)(nil), (*ast.CompositeLit)(nil), (*ast.AssignStmt)(nil)}
+}
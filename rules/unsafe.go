@@ -16,6 +16,8 @@ package rules
 
 import (
 	"go/ast"
+	"go/types"
+	"slices"
 
 	"github.com/securego/gosec/v2"
 	"github.com/securego/gosec/v2/issue"
@@ -25,12 +27,70 @@ type usingUnsafe struct {
 	callListRule
 }
 
-// NewUsingUnsafe rule detects the use of the unsafe package. This is only
-// really useful for auditing purposes.
+// reflectHeaders are the reflect types describing the runtime layout of
+// slices and strings, which are only meaningful when overlaid with unsafe.
+var reflectHeaders = []string{"SliceHeader", "StringHeader"}
+
+func (r *usingUnsafe) Match(n ast.Node, c *gosec.Context) (*issue.Issue, error) {
+	switch node := n.(type) {
+	case *ast.CallExpr:
+		if call := r.calls.ContainsPkgCallExpr(n, c, false); call != nil {
+			// reflect.NewAt(typ, unsafe.Pointer(p)) is reported at the conversion
+			if fn, ok := call.Fun.(*ast.SelectorExpr); ok && fn.Sel.Name == "NewAt" && len(call.Args) == 2 && isUnsafePointerConversion(call.Args[1], c) {
+				return nil, nil
+			}
+			return c.NewIssue(n, r.ID(), r.What, r.Severity, r.Confidence), nil
+		}
+		// Conversions such as (*reflect.SliceHeader)(p), unless p is itself an
+		// unsafe.Pointer conversion reported on its own
+		if tv, ok := c.Info.Types[node.Fun]; ok && tv.IsType() && isReflectHeader(tv.Type) {
+			if len(node.Args) == 1 && isUnsafePointerConversion(node.Args[0], c) {
+				return nil, nil
+			}
+			return c.NewIssue(n, r.ID(), r.What, r.Severity, r.Confidence), nil
+		}
+	case *ast.CompositeLit:
+		if t := c.Info.TypeOf(node); t != nil && isReflectHeader(t) {
+			return c.NewIssue(n, r.ID(), r.What, r.Severity, r.Confidence), nil
+		}
+	}
+	return nil, nil
+}
+
+// isUnsafePointerConversion reports whether expr is a conversion to
+// unsafe.Pointer.
+func isUnsafePointerConversion(expr ast.Expr, c *gosec.Context) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	tv, ok := c.Info.Types[call.Fun]
+	if !ok || !tv.IsType() {
+		return false
+	}
+	basic, ok := tv.Type.(*types.Basic)
+	return ok && basic.Kind() == types.UnsafePointer
+}
+
+func isReflectHeader(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "reflect" {
+		return false
+	}
+	return slices.Contains(reflectHeaders, named.Obj().Name())
+}
+
+// NewUsingUnsafe rule detects the use of the unsafe package, and of the
+// reflect APIs that create values from raw memory. This is only really
+// useful for auditing purposes.
 func NewUsingUnsafe(id string, _ gosec.Config) (gosec.Rule, []ast.Node) {
 	rule := &usingUnsafe{
 		callListRule: newCallListRule(id, "Use of unsafe calls should be audited", issue.Low, issue.High),
 	}
 	rule.AddAll("unsafe", "Pointer", "String", "StringData", "Slice", "SliceData")
-	return rule, []ast.Node{(*ast.CallExpr)(nil)}
+	rule.Add("reflect", "NewAt")
+	return rule, []ast.Node{(*ast.CallExpr)(nil), (*ast.CompositeLit)(nil)}
 }
@@ -3,6 +3,7 @@ package taint
 import (
 	"fmt"
 	"go/token"
+	"maps"
 	"os"
 	"strconv"
 
@@ -26,10 +27,11 @@ type RuleInfo struct {
 // compatible with gosec's analyzer framework.
 func NewGosecAnalyzer(rule *RuleInfo, config *Config) *analysis.Analyzer {
 	return &analysis.Analyzer{
-		Name:     rule.ID,
-		Doc:      rule.Description,
-		Run:      makeAnalyzerRunner(rule, config),
-		Requires: []*analysis.Analyzer{buildssa.Analyzer},
+		Name:      rule.ID,
+		Doc:       rule.Description,
+		Run:       makeAnalyzerRunner(rule, config),
+		Requires:  []*analysis.Analyzer{buildssa.Analyzer},
+		FactTypes: []analysis.Fact{new(FunctionFact)},
 	}
 }
 
@@ -54,12 +56,39 @@ func makeAnalyzerRunner(rule *RuleInfo, config *Config) func(*analysis.Pass) (in
 			return nil, nil // No functions to analyze - this is OK
 		}
 
+		// Honour the trusted and untrusted sources configured for the rule
+		ruleConfig, err := config.WithTrust(ParseTrustProfile(ssaResult.Config[rule.ID]))
+		if err != nil {
+			return nil, fmt.Errorf("taint analysis %s: %w", rule.ID, err)
+		}
+
 		// Run taint analysis
-		analyzer := New(config)
+		analyzer := New(&ruleConfig)
 		if ssaResult.Shared != nil {
 			analyzer.SetCallGraph(ssaResult.Shared.CallGraph())
+			if usesDefaultSources(&ruleConfig) {
+				analyzer.SetSourcePass(sharedSourcePass(ssaResult.Shared, srcFuncs))
+			}
+		}
+		if pass.ImportObjectFact != nil {
+			analyzer.SetFactImporter(func(fn *ssa.Function) (FunctionSummary, bool) {
+				return importFunctionSummary(pass, rule.ID, fn)
+			})
+		}
+		if budget := ssaResult.Budget; budget != nil {
+			analyzer.SetLimits(Limits{
+				MaxDepth:       budget.TaintMaxDepth,
+				MaxCallerEdges: budget.TaintMaxCallerEdges,
+				Deadline:       budget.Deadline,
+			})
 		}
 		results := analyzer.Analyze(srcFuncs[0].Prog, srcFuncs)
+		if pass.ExportObjectFact != nil {
+			exportFunctionSummaries(pass, rule.ID, analyzer.Summaries(srcFuncs))
+		}
+		for _, cutoff := range analyzer.Truncated() {
+			ssaResult.Budget.Truncate(rule.ID, truncationReason(cutoff))
+		}
 
 		// Convert results to gosec issues
 		var issues []*issue.Issue
@@ -86,7 +115,7 @@ func makeAnalyzerRunner(rule *RuleInfo, config *Config) func(*analysis.Pass) (in
 				pass.Fset,
 				result.SinkPos,
 				severity,
-				issue.High, // confidence
+				sourceConfidence(result.Label),
 			)
 
 			issues = append(issues, newIssue)
@@ -102,6 +131,55 @@ func makeAnalyzerRunner(rule *RuleInfo, config *Config) func(*analysis.Pass) (in
 	}
 }
 
+// truncationReason names the budget behind a limit reached by the engine.
+func truncationReason(cutoff Cutoff) string {
+	switch cutoff {
+	case CutoffCallerEdges:
+		return ssautil.TruncatedTaintCallerEdges
+	case CutoffDeadline:
+		return ssautil.TruncatedTimeout
+	default:
+		return ssautil.TruncatedTaintMaxDepth
+	}
+}
+
+// importFunctionSummary looks up the summary of fn exported for the rule by
+// the analysis of fn's package.
+func importFunctionSummary(pass *analysis.Pass, ruleID string, fn *ssa.Function) (FunctionSummary, bool) {
+	if origin := fn.Origin(); origin != nil {
+		fn = origin
+	}
+	obj := fn.Object()
+	if obj == nil {
+		return FunctionSummary{}, false
+	}
+	var fact FunctionFact
+	if !pass.ImportObjectFact(obj, &fact) {
+		return FunctionSummary{}, false
+	}
+	summary, ok := fact.Summaries[ruleID]
+	return summary, ok
+}
+
+// exportFunctionSummaries attaches the summaries of the package's functions
+// to their objects, merging with summaries exported by other rules.
+func exportFunctionSummaries(pass *analysis.Pass, ruleID string, summaries map[*ssa.Function]FunctionSummary) {
+	for fn, summary := range summaries {
+		obj := fn.Object()
+		if obj == nil || obj.Pkg() != pass.Pkg {
+			continue
+		}
+		// The imported map may be shared with other rules running
+		// concurrently, so it is copied rather than updated in place.
+		var existing FunctionFact
+		pass.ImportObjectFact(obj, &existing)
+		merged := make(map[string]FunctionSummary, len(existing.Summaries)+1)
+		maps.Copy(merged, existing.Summaries)
+		merged[ruleID] = summary
+		pass.ExportObjectFact(obj, &FunctionFact{Summaries: merged})
+	}
+}
+
 // newIssue creates a new gosec issue
 func newIssue(analyzerID string, desc string, fileSet *token.FileSet,
 	pos token.Pos, severity, confidence issue.Score,
@@ -9,6 +9,7 @@ import (
 	"go/types"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
@@ -508,7 +509,7 @@ func TestTaintAnalysisPerformanceWithManySinkCalls(t *testing.T) {
 
 	// This test verifies that taint analysis completes in bounded time even when
 	// CHA produces a large call graph (many interface implementations × many sink calls).
-	// Before the maxCallerEdges cap and paramTaintCache, this scenario could hang.
+	// Before the maxCallerEdges cap and parameter summaries, this scenario could hang.
 	prog, srcFuncs := buildManySinkCallsFixture(t)
 
 	analyzer := New(&Config{
@@ -1005,8 +1006,8 @@ func twoParams(r *http.Request, s string) {}
 		Sources: []Source{{Package: "net/http", Name: "Request", Pointer: true}},
 	})
 	// Do NOT call Analyze — callGraph stays nil.
-	// Initialize paramTaintCache so the cache-store branch is exercised.
-	analyzer.paramTaintCache = make(map[paramKey]bool)
+	// Initialize the summaries so the cache-store branch is exercised.
+	analyzer.summaries = make(map[*ssa.Function]*funcSummary)
 
 	// Source-type param → auto-taint (and caches result).
 	visited := make(map[ssa.Value]bool)
@@ -1015,7 +1016,7 @@ func twoParams(r *http.Request, s string) {}
 	}
 
 	// Verify cache was populated.
-	if !analyzer.paramTaintCache[paramKey{fn: fn, paramIdx: 0}] {
+	if !analyzer.isParamKnownTainted(fn, 0) {
 		t.Fatal("expected cache to contain taint result for param 0")
 	}
 
@@ -1077,7 +1078,7 @@ func TestIsParameterTaintedEntryPointCacheStoreAndHit(t *testing.T) {
 	t.Parallel()
 
 	// Exercises the cache-store (line 934) and cache-hit (line 897) branches.
-	// Analyze() sets paramTaintCache to nil on return, so we must invoke
+	// Analyze() sets summaries to nil on return, so we must invoke
 	// isParameterTainted directly while the cache is live. We do this by
 	// manually initialising the analyzer state the same way Analyze does.
 	httpPkg := makeHTTPPkg()
@@ -1117,7 +1118,7 @@ func lonely(r *http.Request) {}
 	})
 	// Manually set up call graph + cache (same as Analyze does internally).
 	analyzer.callGraph = cha.CallGraph(prog)
-	analyzer.paramTaintCache = make(map[paramKey]bool)
+	analyzer.summaries = make(map[*ssa.Function]*funcSummary)
 	analyzer.prog = prog
 
 	// First call: entry point (no callers) + source type → auto-taint + cache store.
@@ -1125,7 +1126,7 @@ func lonely(r *http.Request) {}
 	if !analyzer.isParameterTainted(fn.Params[0], fn, visited, 0) {
 		t.Fatal("expected entry-point source-type param to be tainted")
 	}
-	if !analyzer.paramTaintCache[paramKey{fn: fn, paramIdx: 0}] {
+	if !analyzer.isParamKnownTainted(fn, 0) {
 		t.Fatal("expected cache to be populated")
 	}
 
@@ -1135,3 +1136,735 @@ func lonely(r *http.Request) {}
 		t.Fatal("expected cache hit to return true")
 	}
 }
+
+// ── guard and validator sanitizers ────────────────────────────────────────────
+
+func analyzeGuardFixture(t *testing.T, body string, allowlistMaps bool) []Result {
+	t.Helper()
+
+	src := `package p
+
+import "errors"
+
+var allowed = map[string]bool{"a": true}
+var known = map[string]struct{}{"a": {}}
+var seen = map[string]bool{}
+
+func source() string          { return "" }
+func sink(s string)           {}
+func isOK(s string) bool      { return s != "" }
+func validate(s string) error { if s == "" { return errors.New("empty") }; return nil }
+func replace(s, old, new string) string { return s }
+
+type header map[string]string
+
+var out = header{}
+
+func f() {
+` + body + `
+}
+`
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue), Defs: make(map[*ast.Ident]types.Object),
+		Uses: make(map[*ast.Ident]types.Object), Implicits: make(map[ast.Node]types.Object),
+		Scopes: make(map[ast.Node]*types.Scope), Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	pkg, err := (&types.Config{Importer: fakeImporterFunc(func(path string) (*types.Package, error) {
+		if path == "errors" {
+			errPkg := types.NewPackage("errors", "errors")
+			sig := types.NewSignatureType(nil, nil, nil,
+				types.NewTuple(types.NewVar(token.NoPos, errPkg, "text", types.Typ[types.String])),
+				types.NewTuple(types.NewVar(token.NoPos, errPkg, "", types.Universe.Lookup("error").Type())), false)
+			errPkg.Scope().Insert(types.NewFunc(token.NoPos, errPkg, "New", sig))
+			errPkg.MarkComplete()
+			return errPkg, nil
+		}
+		return nil, fmt.Errorf("unknown %q", path)
+	})}).Check("p", fset, []*ast.File{parsed}, info)
+	if err != nil {
+		t.Fatalf("type-check: %v", err)
+	}
+
+	prog := ssa.NewProgram(fset, 0)
+	for _, imp := range pkg.Imports() {
+		prog.CreatePackage(imp, nil, nil, true)
+	}
+	ssaPkg := prog.CreatePackage(pkg, []*ast.File{parsed}, info, true)
+	prog.Build()
+
+	analyzer := New(&Config{
+		Sources: []Source{{Package: "p", Name: "source", IsFunc: true}},
+		Sinks:   []Sink{{Package: "p", Method: "sink"}, {Package: "p", Receiver: "header", MapStore: true}},
+		Sanitizers: []Sanitizer{
+			{Package: "p", Method: "isOK", Guard: true},
+			{Package: "p", Method: "validate", Validator: true},
+			{Package: "p", Method: "replace", Strips: "\r\n"},
+		},
+		AllowlistMaps: allowlistMaps,
+	})
+	return analyzer.Analyze(prog, []*ssa.Function{ssaPkg.Func("f")})
+}
+
+func TestGuardSanitizers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		body  string
+		taint bool
+	}{
+		{"unguarded", `x := source(); sink(x)`, true},
+		{"map written from tainted key", `x := source(); seen[source()] = true; if !seen[x] { return }; sink(x)`, true},
+		{"local map written from tainted key", `m := map[string]bool{"a": true}; m[source()] = true; x := source(); if !m[x] { return }; sink(x)`, true},
+		{"local allowlist map", `m := map[string]bool{"a": true}; x := source(); if !m[x] { return }; sink(x)`, false},
+		{"guard early return", `x := source(); if !isOK(x) { return }; sink(x)`, false},
+		{"guard true branch", `x := source(); if isOK(x) { sink(x) }`, false},
+		{"guard false branch", `x := source(); if !isOK(x) { sink(x) }`, true},
+		{"validator early return", `x := source(); if err := validate(x); err != nil { return }; sink(x)`, false},
+		{"validator nil branch", `x := source(); if validate(x) == nil { sink(x) }`, false},
+		{"validator error branch", `x := source(); if err := validate(x); err != nil { sink(x) }`, true},
+		{"validator result ignored", `x := source(); _ = validate(x); sink(x)`, true},
+		{"map bool membership", `x := source(); if !allowed[x] { return }; sink(x)`, false},
+		{"map comma-ok membership", `x := source(); if _, ok := known[x]; ok { sink(x) }`, false},
+		{"map comma-ok missing", `x := source(); if _, ok := known[x]; !ok { sink(x) }`, true},
+		{"guard on other value", `x := source(); y := source(); if !isOK(y) { return }; sink(x)`, true},
+		{"guard on captured variable", `x := source(); defer func() { _ = x }(); if !isOK(x) { return }; sink(x)`, false},
+		{"captured variable reassigned after guard", `x := source(); defer func() { _ = x }(); if !isOK(x) { return }; x = source(); sink(x)`, true},
+		{"captured variable reassigned before guard", `x := source(); defer func() { _ = x }(); x = source(); if !isOK(x) { return }; sink(x)`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			results := analyzeGuardFixture(t, tt.body, true)
+			if got := len(results) > 0; got != tt.taint {
+				t.Fatalf("expected tainted=%v, got %d results", tt.taint, len(results))
+			}
+		})
+	}
+}
+
+func TestStripSanitizers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		body  string
+		taint bool
+	}{
+		{"CR and LF stripped", `sink(replace(replace(source(), "\r", ""), "\n", ""))`, false},
+		{"CR and LF stripped before a map store", `out["k"] = replace(replace(source(), "\n", ""), "\r", "")`, false},
+		{"CR and LF stripped before concatenation", `x := replace(replace(source(), "\r", ""), "\n", ""); sink("v=" + x)`, false},
+		{"only CR stripped", `sink(replace(source(), "\r", ""))`, true},
+		{"only LF stripped before a map store", `out["k"] = replace(source(), "\n", "")`, true},
+		{"CRLF sequence stripped", `sink(replace(source(), "\r\n", ""))`, true},
+		{"LF replaced with CR", `sink(replace(replace(source(), "\r", ""), "\n", "\r"))`, true},
+		{"non-constant replacement", `sink(replace(replace(source(), "\r", ""), "\n", source()))`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			results := analyzeGuardFixture(t, tt.body, false)
+			if got := len(results) > 0; got != tt.taint {
+				t.Fatalf("expected tainted=%v, got %d results", tt.taint, len(results))
+			}
+		})
+	}
+}
+
+func TestAllowlistMapsAreOptIn(t *testing.T) {
+	t.Parallel()
+
+	results := analyzeGuardFixture(t, `x := source(); if !allowed[x] { return }; sink(x)`, false)
+	if len(results) == 0 {
+		t.Fatal("expected map membership not to sanitize without AllowlistMaps")
+	}
+}
+
+// ── function summaries ────────────────────────────────────────────────────────
+
+func TestParamFlowsToReturnIsSummarized(t *testing.T) {
+	t.Parallel()
+
+	src := `package p
+
+func pick(a, b string) string { _ = b; return a + "!" }
+`
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue), Defs: make(map[*ast.Ident]types.Object),
+		Uses: make(map[*ast.Ident]types.Object), Implicits: make(map[ast.Node]types.Object),
+		Scopes: make(map[ast.Node]*types.Scope), Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	pkg, err := (&types.Config{}).Check("p", fset, []*ast.File{parsed}, info)
+	if err != nil {
+		t.Fatalf("type-check: %v", err)
+	}
+	prog := ssa.NewProgram(fset, 0)
+	ssaPkg := prog.CreatePackage(pkg, []*ast.File{parsed}, info, true)
+	prog.Build()
+	fn := ssaPkg.Func("pick")
+
+	analyzer := New(&Config{})
+	analyzer.summaries = make(map[*ssa.Function]*funcSummary)
+
+	if !analyzer.paramFlowsToReturn(fn, 0) {
+		t.Fatal("expected param a to flow to the return value")
+	}
+	if analyzer.paramFlowsToReturn(fn, 1) {
+		t.Fatal("expected param b not to flow to the return value")
+	}
+	if analyzer.paramFlowsToReturn(fn, 2) {
+		t.Fatal("expected out-of-range param index to be false")
+	}
+	if s := analyzer.summaries[fn]; s == nil || len(s.paramsToReturn) != 2 {
+		t.Fatal("expected summary to record flows for both params")
+	}
+}
+
+// ── cross-package summaries ───────────────────────────────────────────────────
+
+func TestSummariesRecordSinkAndReturnParams(t *testing.T) {
+	t.Parallel()
+
+	src := `package p
+
+func sink(s string) {}
+
+func Save(prefix, s string) { store(s) }
+func store(s string)        { sink("v=" + s) }
+func Wrap(s string) string  { return "<" + s + ">" }
+func Drop(s string) string  { return "const" }
+`
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue), Defs: make(map[*ast.Ident]types.Object),
+		Uses: make(map[*ast.Ident]types.Object), Implicits: make(map[ast.Node]types.Object),
+		Scopes: make(map[ast.Node]*types.Scope), Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	pkg, err := (&types.Config{}).Check("p", fset, []*ast.File{parsed}, info)
+	if err != nil {
+		t.Fatalf("type-check: %v", err)
+	}
+	prog := ssa.NewProgram(fset, 0)
+	ssaPkg := prog.CreatePackage(pkg, []*ast.File{parsed}, info, true)
+	prog.Build()
+
+	var srcFuncs []*ssa.Function
+	for _, name := range []string{"sink", "Save", "store", "Wrap", "Drop"} {
+		srcFuncs = append(srcFuncs, ssaPkg.Func(name))
+	}
+
+	analyzer := New(&Config{Sinks: []Sink{{Package: "p", Method: "sink"}}})
+	summaries := analyzer.Summaries(srcFuncs)
+
+	save := summaries[ssaPkg.Func("Save")]
+	if got := save.SinkParams[1]; len(got) != 1 || got[0] != "p.sink" {
+		t.Fatalf("expected Save param 1 to reach p.sink through store, got %v", save.SinkParams)
+	}
+	if _, ok := save.SinkParams[0]; ok {
+		t.Fatal("expected Save param 0 not to reach a sink")
+	}
+	if wrap := summaries[ssaPkg.Func("Wrap")]; len(wrap.ReturnParams) != 1 || wrap.ReturnParams[0] != 0 {
+		t.Fatalf("expected Wrap param 0 to flow to its return, got %v", wrap.ReturnParams)
+	}
+	if _, ok := summaries[ssaPkg.Func("Drop")]; ok {
+		t.Fatal("expected no summary for Drop")
+	}
+
+	fact := &FunctionFact{Summaries: map[string]FunctionSummary{"G701": save}}
+	if got, want := fact.String(), "taint(G701{sinks:[1->p.sink] returns:[]})"; got != want {
+		t.Fatalf("unexpected fact string %q, want %q", got, want)
+	}
+}
+
+// ── source labels ─────────────────────────────────────────────────────────────
+
+// buildLabelFixture builds package p from src, importing a fake "os" package
+// that provides Getenv.
+func buildLabelFixture(t *testing.T, src string) (*ssa.Program, *ssa.Package) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue), Defs: make(map[*ast.Ident]types.Object),
+		Uses: make(map[*ast.Ident]types.Object), Implicits: make(map[ast.Node]types.Object),
+		Scopes: make(map[ast.Node]*types.Scope), Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	pkg, err := (&types.Config{Importer: fakeImporterFunc(func(path string) (*types.Package, error) {
+		if path == "os" {
+			osPkg := types.NewPackage("os", "os")
+			sig := types.NewSignatureType(nil, nil, nil,
+				types.NewTuple(types.NewVar(token.NoPos, osPkg, "key", types.Typ[types.String])),
+				types.NewTuple(types.NewVar(token.NoPos, osPkg, "", types.Typ[types.String])), false)
+			osPkg.Scope().Insert(types.NewFunc(token.NoPos, osPkg, "Getenv", sig))
+			osPkg.MarkComplete()
+			return osPkg, nil
+		}
+		if path == "github.com/redis/go-redis/v9" {
+			return fakeRedisPackage(), nil
+		}
+		return nil, fmt.Errorf("unknown %q", path)
+	})}).Check("p", fset, []*ast.File{parsed}, info)
+	if err != nil {
+		t.Fatalf("type-check: %v", err)
+	}
+
+	prog := ssa.NewProgram(fset, 0)
+	for _, imp := range pkg.Imports() {
+		prog.CreatePackage(imp, nil, nil, true)
+	}
+	ssaPkg := prog.CreatePackage(pkg, []*ast.File{parsed}, info, true)
+	prog.Build()
+	return prog, ssaPkg
+}
+
+// fakeRedisPackage declares the part of go-redis used by the fixtures:
+// (*Client).Get returning a *StringCmd, whose Val method returns the reply.
+func fakeRedisPackage() *types.Package {
+	pkg := types.NewPackage("github.com/redis/go-redis/v9", "redis")
+	str := func(name string) *types.Var { return types.NewVar(token.NoPos, pkg, name, types.Typ[types.String]) }
+
+	cmd := types.NewNamed(types.NewTypeName(token.NoPos, pkg, "StringCmd", nil), types.NewStruct(nil, nil), nil)
+	cmd.AddMethod(types.NewFunc(token.NoPos, pkg, "Val", types.NewSignatureType(
+		types.NewVar(token.NoPos, pkg, "cmd", types.NewPointer(cmd)), nil, nil, nil, types.NewTuple(str("")), false)))
+	client := types.NewNamed(types.NewTypeName(token.NoPos, pkg, "Client", nil), types.NewStruct(nil, nil), nil)
+	client.AddMethod(types.NewFunc(token.NoPos, pkg, "Get", types.NewSignatureType(
+		types.NewVar(token.NoPos, pkg, "c", types.NewPointer(client)), nil, nil,
+		types.NewTuple(str("key")), types.NewTuple(types.NewVar(token.NoPos, pkg, "", types.NewPointer(cmd))), false)))
+
+	pkg.Scope().Insert(cmd.Obj())
+	pkg.Scope().Insert(client.Obj())
+	pkg.MarkComplete()
+	return pkg
+}
+
+func TestSinkLabelsSelectSources(t *testing.T) {
+	t.Parallel()
+
+	prog, ssaPkg := buildLabelFixture(t, `package p
+
+import "os"
+
+func query() string     { return "" }
+func execSink(s string) {}
+func htmlSink(s string) {}
+
+func f() {
+	execSink(os.Getenv("CMD"))
+	htmlSink(os.Getenv("TITLE"))
+	htmlSink(query())
+}
+
+func g(s string) {
+	execSink(s)
+	htmlSink(s)
+}
+
+func h() { g(os.Getenv("X")) }
+`)
+
+	analyzer := New(&Config{
+		Sources: []Source{
+			{Package: "os", Name: "Getenv", IsFunc: true, Label: LabelEnv},
+			{Package: "p", Name: "query", IsFunc: true, Label: LabelHTTPInput},
+		},
+		Sinks: []Sink{
+			{Package: "p", Method: "execSink", Labels: LabelEnv | LabelHTTPInput},
+			{Package: "p", Method: "htmlSink", Labels: LabelHTTPInput},
+		},
+	})
+	results := analyzer.Analyze(prog, []*ssa.Function{ssaPkg.Func("f"), ssaPkg.Func("g"), ssaPkg.Func("h")})
+
+	got := make(map[string]int)
+	for _, r := range results {
+		got[r.Sink.Method]++
+	}
+	// execSink in f and g; htmlSink only for query() in f, since a parameter
+	// proven env-tainted for execSink must not be reused for htmlSink.
+	if got["execSink"] != 2 || got["htmlSink"] != 1 {
+		t.Fatalf("unexpected results per sink: %v", got)
+	}
+}
+
+func TestSourcePassPrunesCleanArguments(t *testing.T) {
+	t.Parallel()
+
+	prog, ssaPkg := buildLabelFixture(t, `package p
+
+import "os"
+
+func sink(s string) {}
+
+func f() {
+	sink("static")
+	sink("v=" + os.Getenv("V"))
+}
+`)
+	fn := ssaPkg.Func("f")
+	var args []ssa.Value
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			if call, ok := instr.(*ssa.Call); ok && call.Call.StaticCallee() == ssaPkg.Func("sink") {
+				args = append(args, call.Call.Args[0])
+			}
+		}
+	}
+	if len(args) != 2 {
+		t.Fatalf("expected 2 sink calls, got %d", len(args))
+	}
+
+	pass := NewSourcePass(prog, nil, nil)
+	if pass.MayBeTainted(args[0], 0) {
+		t.Fatal("expected constant argument to be clean")
+	}
+	if got := pass.Labels(args[1]); got != LabelEnv {
+		t.Fatalf("expected os.Getenv argument to be labelled env, got %v", got)
+	}
+	if pass.MayBeTainted(args[1], LabelHTTPInput) {
+		t.Fatal("expected os.Getenv argument to be irrelevant to http-input sinks")
+	}
+
+	analyzer := New(&Config{
+		Sources: SourcesWithLabels(LabelEnv),
+		Sinks:   []Sink{{Package: "p", Method: "sink", Labels: LabelEnv}},
+	})
+	analyzer.SetSourcePass(pass)
+	if results := analyzer.Analyze(prog, []*ssa.Function{fn}); len(results) != 1 {
+		t.Fatalf("expected 1 result with the source pass, got %d", len(results))
+	}
+}
+
+func TestUsesDefaultSources(t *testing.T) {
+	t.Parallel()
+
+	if !usesDefaultSources(&Config{Sources: SourcesWithLabels(LabelHTTPInput | LabelCLI)}) {
+		t.Fatal("expected catalog subset to use default sources")
+	}
+	if usesDefaultSources(&Config{Sources: []Source{{Package: "p", Name: "source", IsFunc: true}}}) {
+		t.Fatal("expected custom source not to use default sources")
+	}
+	if got := (LabelHTTPInput | LabelCLI).String(); got != "http-input|cli" {
+		t.Fatalf("unexpected label string %q", got)
+	}
+}
+
+// ── trust profiles ────────────────────────────────────────────────────────────
+
+func TestConfigWithTrust(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		Sources: SourcesWithLabels(LabelUserInput),
+		Sinks:   []Sink{{Package: "os/exec", Method: "Command", Labels: LabelUserInput}, {Package: "p", Method: "any"}},
+	}
+	profile := ParseTrustProfile(map[string]any{
+		"trusted":   []any{"os.Args", "env"},
+		"untrusted": []any{"file-content"},
+	})
+
+	got, err := config.WithTrust(profile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, src := range got.Sources {
+		if src.Name == "Args" || src.Name == "Getenv" {
+			t.Fatalf("expected %s.%s to be trusted", src.Package, src.Name)
+		}
+	}
+	if !slices.ContainsFunc(got.Sources, func(s Source) bool { return s.Name == "ReadFile" }) {
+		t.Fatal("expected os.ReadFile to be untrusted")
+	}
+	if got.Sinks[0].Labels != LabelUserInput|LabelFileContent {
+		t.Fatalf("expected file-content label on labelled sink, got %v", got.Sinks[0].Labels)
+	}
+	if got.Sinks[1].Labels != 0 {
+		t.Fatalf("expected unlabelled sink to stay unlabelled, got %v", got.Sinks[1].Labels)
+	}
+	if config.Sinks[0].Labels != LabelUserInput || len(config.Sources) != len(SourcesWithLabels(LabelUserInput)) {
+		t.Fatal("expected the original config to be left untouched")
+	}
+
+	if _, err := config.WithTrust(TrustProfile{Trusted: []string{"os.Stdin"}}); err == nil {
+		t.Fatal("expected an error for an unknown source")
+	}
+}
+
+func TestConfigWithValidators(t *testing.T) {
+	t.Parallel()
+
+	profile := ParseTrustProfile(map[string]any{
+		"validators": []any{"example.com/app/check.ID"},
+		"guards":     []any{"(*example.com/app.Policy).Allowed"},
+	})
+	got, err := Config{}.WithTrust(profile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Sanitizer{
+		{Package: "example.com/app/check", Method: "ID", Validator: true},
+		{Package: "example.com/app", Receiver: "Policy", Method: "Allowed", Pointer: true, Guard: true},
+	}
+	if !slices.Equal(got.Sanitizers, want) {
+		t.Fatalf("expected sanitizers %+v, got %+v", want, got.Sanitizers)
+	}
+
+	for _, name := range []string{"check", "(example.com/app.Policy)", "(*Policy).Allowed"} {
+		if _, err := (Config{}).WithTrust(TrustProfile{Validators: []string{name}}); err == nil {
+			t.Fatalf("expected an error for %q", name)
+		}
+	}
+}
+
+func TestResultLabelReflectsSource(t *testing.T) {
+	t.Parallel()
+
+	prog, ssaPkg := buildLabelFixture(t, `package p
+
+import "os"
+
+func query() string     { return "" }
+func execSink(s string) {}
+
+func f() {
+	execSink(os.Getenv("CMD"))
+	execSink(query() + os.Getenv("ARGS"))
+}
+`)
+
+	analyzer := New(&Config{
+		Sources: []Source{
+			{Package: "os", Name: "Getenv", IsFunc: true, Label: LabelEnv},
+			{Package: "p", Name: "query", IsFunc: true, Label: LabelHTTPInput},
+		},
+		Sinks: []Sink{{Package: "p", Method: "execSink", Labels: LabelEnv | LabelHTTPInput}},
+	})
+	results := analyzer.Analyze(prog, []*ssa.Function{ssaPkg.Func("f")})
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Label != LabelEnv || results[1].Label != LabelHTTPInput {
+		t.Fatalf("unexpected labels %v and %v", results[0].Label, results[1].Label)
+	}
+	if sourceConfidence(results[0].Label) != issue.Medium || sourceConfidence(results[1].Label) != issue.High {
+		t.Fatal("expected env flows to have medium and http flows high confidence")
+	}
+}
+
+func TestStoredSources(t *testing.T) {
+	t.Parallel()
+
+	prog, ssaPkg := buildLabelFixture(t, `package p
+
+import "github.com/redis/go-redis/v9"
+
+func htmlSink(s string) {}
+
+func f(rdb *redis.Client) {
+	htmlSink(rdb.Get("greeting").Val())
+}
+`)
+
+	config := Config{
+		Sources: DefaultSources,
+		Sinks:   []Sink{{Package: "p", Method: "htmlSink", Labels: LabelHTTPInput}},
+	}
+	results := New(&config).Analyze(prog, []*ssa.Function{ssaPkg.Func("f")})
+	if len(results) != 0 {
+		t.Fatalf("expected stored data to be trusted by default, got %d results", len(results))
+	}
+
+	stored, err := config.WithTrust(TrustProfile{Untrusted: []string{"stored"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	analyzer := New(&stored)
+	analyzer.SetSourcePass(NewSourcePass(prog, nil, nil))
+	results = analyzer.Analyze(prog, []*ssa.Function{ssaPkg.Func("f")})
+	if len(results) != 1 {
+		t.Fatalf("expected the redis reply to reach the sink, got %d results", len(results))
+	}
+	if results[0].Label != LabelStored || sourceConfidence(results[0].Label) != issue.Low {
+		t.Fatalf("expected a low confidence stored flow, got %v", results[0].Label)
+	}
+
+	if _, err := config.WithTrust(TrustProfile{TrustedFiles: []string{"("}}); err == nil {
+		t.Fatal("expected an invalid trusted file pattern to be rejected")
+	}
+}
+
+func TestSinksReachedThroughFunctionValues(t *testing.T) {
+	t.Parallel()
+
+	prog, ssaPkg := buildLabelFixture(t, `package p
+
+import "os"
+
+type db struct{}
+
+func (*db) exec(q string) {}
+
+func execSink(s string) {}
+
+type server struct {
+	run  func(string)
+	exec func(string)
+}
+
+func newServer(d *db) *server { return &server{run: execSink, exec: d.exec} }
+
+func (s *server) viaField()       { s.run(os.Getenv("A")) }
+func (s *server) viaMethodField() { s.exec(os.Getenv("B")) }
+
+func viaGo()     { go execSink(os.Getenv("C")) }
+func viaDefer()  { defer execSink(os.Getenv("D")) }
+func viaMethod(d *db) {
+	run := d.exec
+	run(os.Getenv("E"))
+}
+func safe(s *server) { s.run("constant") }
+`)
+
+	analyzer := New(&Config{
+		Sources: DefaultSources,
+		Sinks: []Sink{
+			{Package: "p", Method: "execSink", Labels: LabelEnv},
+			{Package: "p", Receiver: "db", Method: "exec", Pointer: true, CheckArgs: []int{1}, Labels: LabelEnv},
+		},
+	})
+	var srcFuncs []*ssa.Function
+	for _, name := range []string{"viaGo", "viaDefer", "viaMethod", "safe"} {
+		srcFuncs = append(srcFuncs, ssaPkg.Func(name))
+	}
+	serverPtr := types.NewPointer(ssaPkg.Type("server").Type())
+	srcFuncs = append(srcFuncs,
+		prog.LookupMethod(serverPtr, ssaPkg.Pkg, "viaField"),
+		prog.LookupMethod(serverPtr, ssaPkg.Pkg, "viaMethodField"),
+		ssaPkg.Func("newServer"))
+	analyzer.SetSourcePass(NewSourcePass(prog, nil, srcFuncs))
+
+	results := analyzer.Analyze(prog, srcFuncs)
+	if len(results) != 5 {
+		t.Fatalf("expected 5 results, got %d", len(results))
+	}
+}
+
+func TestLimitsTruncateAnalysis(t *testing.T) {
+	t.Parallel()
+
+	prog, ssaPkg := buildLabelFixture(t, `package p
+
+import "os"
+
+func sink(s string) {}
+
+func first(s string)  { second(s) }
+func second(s string) { third(s) }
+func third(s string)  { sink(s) }
+
+func entry() { first(os.Getenv("A")) }
+`)
+
+	config := &Config{
+		Sources: DefaultSources,
+		Sinks:   []Sink{{Package: "p", Method: "sink"}},
+	}
+	var srcFuncs []*ssa.Function
+	for _, name := range []string{"entry", "first", "second", "third"} {
+		srcFuncs = append(srcFuncs, ssaPkg.Func(name))
+	}
+
+	unbounded := New(config)
+	if results := unbounded.Analyze(prog, srcFuncs); len(results) != 1 {
+		t.Fatalf("expected 1 result without limits, got %d", len(results))
+	}
+	if truncated := unbounded.Truncated(); len(truncated) != 0 {
+		t.Fatalf("expected a complete analysis, got %v", truncated)
+	}
+
+	shallow := New(config)
+	shallow.SetLimits(Limits{MaxDepth: 2})
+	if results := shallow.Analyze(prog, srcFuncs); len(results) != 0 {
+		t.Fatalf("expected the depth limit to hide the result, got %d", len(results))
+	}
+	if truncated := shallow.Truncated(); !slices.Equal(truncated, []Cutoff{CutoffDepth}) {
+		t.Fatalf("expected a depth truncation, got %v", truncated)
+	}
+
+	late := New(config)
+	late.SetLimits(Limits{Deadline: time.Now().Add(-time.Second)})
+	if results := late.Analyze(prog, srcFuncs); len(results) != 0 {
+		t.Fatalf("expected no result past the deadline, got %d", len(results))
+	}
+	if truncated := late.Truncated(); !slices.Equal(truncated, []Cutoff{CutoffDeadline}) {
+		t.Fatalf("expected a deadline truncation, got %v", truncated)
+	}
+}
+
+func TestMakeSinks(t *testing.T) {
+	t.Parallel()
+
+	prog, ssaPkg := buildLabelFixture(t, `package p
+
+func size() int { return 1 << 40 }
+
+func slice()    { _ = make([]byte, size()) }
+func capacity() { _ = make([]byte, 0, size()) }
+func hint()     { _ = make(map[string]int, size()) }
+func buffer()   { _ = make(chan int, size()) }
+func constant() { _ = make([]byte, 64) }
+func bounded() {
+	n := size()
+	if n > 64 {
+		return
+	}
+	_ = make([]byte, n)
+}
+`)
+
+	var srcFuncs []*ssa.Function
+	for _, name := range []string{"slice", "capacity", "hint", "buffer", "constant", "bounded"} {
+		srcFuncs = append(srcFuncs, ssaPkg.Func(name))
+	}
+	config := &Config{
+		Sources: []Source{{Package: "p", Name: "size", IsFunc: true}},
+		Sinks:   []Sink{{Make: true}},
+	}
+	if results := New(config).Analyze(prog, srcFuncs); len(results) != 5 {
+		t.Fatalf("expected 5 results, got %d", len(results))
+	}
+
+	lengthOnly := &Config{
+		Sources: config.Sources,
+		Sinks:   []Sink{{Make: true, CheckArgs: []int{0}}},
+	}
+	if results := New(lengthOnly).Analyze(prog, srcFuncs); len(results) != 4 {
+		t.Fatalf("expected the capacity to be skipped, got %d results", len(results))
+	}
+
+	boundedFn := ssaPkg.Func("bounded")
+	config.Bounded = func(v ssa.Value, block *ssa.BasicBlock, _ func(int) bool) bool {
+		return block.Parent() == boundedFn
+	}
+	if results := New(config).Analyze(prog, srcFuncs); len(results) != 4 {
+		t.Fatalf("expected the bounded size to be skipped, got %d results", len(results))
+	}
+}
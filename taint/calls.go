@@ -0,0 +1,192 @@
+package taint
+
+import (
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// maxFuncValueDepth bounds the resolution of function values through phis,
+// closures and struct fields.
+const maxFuncValueDepth = 8
+
+// fieldRef identifies a field of a struct type.
+type fieldRef struct {
+	structType string
+	field      int
+}
+
+// sinkCall returns the sink invoked by a call instruction (*ssa.Call, *ssa.Go
+// or *ssa.Defer) and the arguments the sink receives, indexed like the
+// arguments of a static call to it.
+//
+// Besides static and interface calls, it resolves calls of function values:
+// method values (h := db.Query; h(q)) and functions or method values stored
+// in struct fields, phis or captured variables.
+func (a *Analyzer) sinkCall(instr ssa.CallInstruction) (Sink, []ssa.Value, bool) {
+	common := instr.Common()
+	if sink, ok := a.isSinkCall(common); ok {
+		return sink, common.Args, true
+	}
+	if common.IsInvoke() {
+		return Sink{}, nil, false
+	}
+	if _, ok := common.Value.(*ssa.Function); ok {
+		return Sink{}, nil, false
+	}
+
+	for _, target := range a.funcValueTargets(common.Value, make(map[ssa.Value]bool), 0) {
+		switch fn := target.(type) {
+		case *ssa.Function:
+			if sink, ok := a.isSinkFunc(fn); ok {
+				return sink, common.Args, true
+			}
+		case *ssa.MakeClosure:
+			// A method value binds its receiver to a synthetic wrapper
+			// calling the method with the remaining arguments.
+			method := boundMethod(fn)
+			if method == nil || len(fn.Bindings) == 0 {
+				continue
+			}
+			if sink, ok := a.isSinkFunc(method); ok {
+				args := make([]ssa.Value, 0, len(common.Args)+1)
+				args = append(args, fn.Bindings[0])
+				return sink, append(args, common.Args...), true
+			}
+		}
+	}
+	return Sink{}, nil, false
+}
+
+// boundMethod returns the method wrapped by the closure of a method value.
+func boundMethod(mc *ssa.MakeClosure) *ssa.Function {
+	wrapper, ok := mc.Fn.(*ssa.Function)
+	if !ok || !strings.HasPrefix(wrapper.Synthetic, "bound method wrapper") {
+		return nil
+	}
+	method, ok := wrapper.Object().(*types.Func)
+	if !ok || wrapper.Prog == nil {
+		return nil
+	}
+	return wrapper.Prog.FuncValue(method)
+}
+
+// funcValueTargets returns the functions and closures a function value may
+// hold. Values loaded from struct fields resolve to every value stored into
+// the same field of the same struct type.
+func (a *Analyzer) funcValueTargets(v ssa.Value, visited map[ssa.Value]bool, depth int) []ssa.Value {
+	if v == nil || depth > maxFuncValueDepth || visited[v] {
+		return nil
+	}
+	visited[v] = true
+
+	switch val := v.(type) {
+	case *ssa.Function, *ssa.MakeClosure:
+		return []ssa.Value{val}
+	case *ssa.ChangeType:
+		return a.funcValueTargets(val.X, visited, depth+1)
+	case *ssa.Phi:
+		var targets []ssa.Value
+		for _, edge := range val.Edges {
+			targets = append(targets, a.funcValueTargets(edge, visited, depth+1)...)
+		}
+		return targets
+	case *ssa.FreeVar:
+		var targets []ssa.Value
+		for _, binding := range closureBindings(val) {
+			targets = append(targets, a.funcValueTargets(binding, visited, depth+1)...)
+		}
+		return targets
+	case *ssa.Field:
+		return a.fieldTargets(fieldRefOf(val.X.Type(), val.Field), visited, depth)
+	case *ssa.UnOp:
+		if val.Op != token.MUL {
+			return nil
+		}
+		return a.storedTargets(val.X, visited, depth)
+	}
+	return nil
+}
+
+// storedTargets returns the function values stored at the address ptr.
+func (a *Analyzer) storedTargets(ptr ssa.Value, visited map[ssa.Value]bool, depth int) []ssa.Value {
+	switch addr := ptr.(type) {
+	case *ssa.FieldAddr:
+		return a.fieldTargets(fieldRefOf(addr.X.Type(), addr.Field), visited, depth)
+	case *ssa.FreeVar:
+		var targets []ssa.Value
+		for _, binding := range closureBindings(addr) {
+			targets = append(targets, a.storedTargets(binding, visited, depth+1)...)
+		}
+		return targets
+	case *ssa.Alloc:
+		var targets []ssa.Value
+		for _, ref := range *addr.Referrers() {
+			if store, ok := ref.(*ssa.Store); ok && store.Addr == addr {
+				targets = append(targets, a.funcValueTargets(store.Val, visited, depth+1)...)
+			}
+		}
+		return targets
+	}
+	return nil
+}
+
+// fieldTargets returns the function values stored into a struct field.
+func (a *Analyzer) fieldTargets(ref fieldRef, visited map[ssa.Value]bool, depth int) []ssa.Value {
+	var targets []ssa.Value
+	for _, val := range a.fieldStoreIndex()[ref] {
+		targets = append(targets, a.funcValueTargets(val, visited, depth+1)...)
+	}
+	return targets
+}
+
+// fieldStoreIndex returns the function values stored into struct fields by
+// the analyzed functions and the functions of the call graph, built on first use.
+func (a *Analyzer) fieldStoreIndex() map[fieldRef][]ssa.Value {
+	if a.fieldStores != nil {
+		return a.fieldStores
+	}
+	a.fieldStores = make(map[fieldRef][]ssa.Value)
+	funcs := make(map[*ssa.Function]bool, len(a.srcFuncs))
+	for _, fn := range a.srcFuncs {
+		funcs[fn] = true
+	}
+	if a.callGraph != nil {
+		for fn := range a.callGraph.Nodes {
+			funcs[fn] = true
+		}
+	}
+	for fn := range funcs {
+		if fn == nil {
+			continue
+		}
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				store, ok := instr.(*ssa.Store)
+				if !ok {
+					continue
+				}
+				addr, ok := store.Addr.(*ssa.FieldAddr)
+				if !ok {
+					continue
+				}
+				if _, isFunc := store.Val.Type().Underlying().(*types.Signature); !isFunc {
+					continue
+				}
+				ref := fieldRefOf(addr.X.Type(), addr.Field)
+				a.fieldStores[ref] = append(a.fieldStores[ref], store.Val)
+			}
+		}
+	}
+	return a.fieldStores
+}
+
+// fieldRefOf identifies field idx of the struct t, or of the struct t points to.
+func fieldRefOf(t types.Type, idx int) fieldRef {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	return fieldRef{structType: t.String(), field: idx}
+}
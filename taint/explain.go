@@ -0,0 +1,163 @@
+package taint
+
+import (
+	"slices"
+
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/ssa"
+)
+
+// Cutoff names the reason why the exploration of a value stopped before its
+// origins were known.
+type Cutoff string
+
+const (
+	// CutoffDepth marks values beyond the depth limit, maxTaintDepth by default.
+	CutoffDepth Cutoff = "maxTaintDepth"
+	// CutoffCallerEdges marks parameters with more callers than the caller
+	// edge limit, maxCallerEdges by default.
+	CutoffCallerEdges Cutoff = "maxCallerEdges"
+	// CutoffContextType marks context.Context arguments, which never carry taint.
+	CutoffContextType Cutoff = "context type"
+	// CutoffSanitizer marks calls of a configured sanitizer.
+	CutoffSanitizer Cutoff = "sanitizer"
+	// CutoffGuard marks values validated by a guard sanitizer before the sink.
+	CutoffGuard Cutoff = "guard"
+	// CutoffVisited marks values already explored on the same path.
+	CutoffVisited Cutoff = "visited"
+	// CutoffDeadline marks an analysis stopped by the deadline of its limits.
+	CutoffDeadline Cutoff = "deadline"
+)
+
+// TraceNode is a value explored by the taint engine, with the values it was
+// traced back to.
+type TraceNode struct {
+	Value    ssa.Value
+	Func     *ssa.Function
+	Tainted  bool
+	Cutoff   Cutoff
+	Children []*TraceNode
+}
+
+// SinkTrace is the exploration of one checked argument of a sink call.
+type SinkTrace struct {
+	Sink Sink
+	Call ssa.CallInstruction
+	Func *ssa.Function
+	// Arg is the index of the argument, as in Sink.CheckArgs.
+	Arg int
+	// Skipped explains why the call was not checked, if it was not.
+	Skipped string
+	Root    *TraceNode
+}
+
+// tracer records the values explored by isTainted while explaining a sink.
+type tracer struct {
+	stack []*TraceNode
+	root  *TraceNode
+}
+
+func (t *tracer) enter(v ssa.Value, fn *ssa.Function) *TraceNode {
+	node := &TraceNode{Value: v, Func: fn}
+	if len(t.stack) > 0 {
+		parent := t.stack[len(t.stack)-1]
+		parent.Children = append(parent.Children, node)
+	} else if t.root == nil {
+		t.root = node
+	}
+	t.stack = append(t.stack, node)
+	return node
+}
+
+func (t *tracer) exit(node *TraceNode, tainted bool) {
+	node.Tainted = tainted
+	t.stack = t.stack[:len(t.stack)-1]
+}
+
+// traced runs check for v under a new trace node.
+func (a *Analyzer) traced(v ssa.Value, fn *ssa.Function, check func() bool) bool {
+	node := a.tracer.enter(v, fn)
+	tainted := check()
+	a.tracer.exit(node, tainted)
+	return tainted
+}
+
+// cut records why the exploration of v stopped: on the value being explored,
+// or on a leaf for a value that was not explored at all.
+func (a *Analyzer) cut(v ssa.Value, reason Cutoff) {
+	if a.tracer == nil || len(a.tracer.stack) == 0 {
+		return
+	}
+	top := a.tracer.stack[len(a.tracer.stack)-1]
+	if top.Value == v && top.Cutoff == "" {
+		top.Cutoff = reason
+		return
+	}
+	top.Children = append(top.Children, &TraceNode{Value: v, Func: top.Func, Cutoff: reason})
+}
+
+// Explain traces the checked arguments of the sink calls of srcFuncs selected
+// by match, recording every value the engine explores. It is meant for
+// debugging rules and configurations, not for analysis.
+func (a *Analyzer) Explain(prog *ssa.Program, srcFuncs []*ssa.Function, match func(ssa.CallInstruction) bool) []SinkTrace {
+	a.prog = prog
+	if a.callGraph == nil {
+		a.callGraph = cha.CallGraph(prog)
+	}
+	a.srcFuncs = srcFuncs
+	a.fieldStores = nil
+	a.globalUses = nil
+	defer func() { a.summaries, a.tracer, a.sinkBlock, a.labels = nil, nil, nil, 0 }()
+
+	var traces []SinkTrace
+	for _, fn := range srcFuncs {
+		if fn == nil {
+			continue
+		}
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				call, ok := instr.(ssa.CallInstruction)
+				if !ok || !match(call) {
+					continue
+				}
+				sink, args, isSink := a.sinkCall(call)
+				if !isSink {
+					continue
+				}
+				if !guardsSatisfied(args, sink, a.prog) {
+					traces = append(traces, SinkTrace{Sink: sink, Call: call, Func: fn, Arg: -1, Skipped: "argument type guards not satisfied"})
+					continue
+				}
+				for idx, arg := range args {
+					if len(sink.CheckArgs) > 0 && !slices.Contains(sink.CheckArgs, idx) {
+						continue
+					}
+					traces = append(traces, a.explainArg(sink, call, fn, block, idx, arg))
+				}
+			}
+		}
+	}
+	return traces
+}
+
+// explainArg traces one sink argument with fresh summaries, so that the
+// trace shows the whole exploration rather than cached conclusions.
+func (a *Analyzer) explainArg(sink Sink, call ssa.CallInstruction, fn *ssa.Function, block *ssa.BasicBlock, idx int, arg ssa.Value) SinkTrace {
+	a.summaries = make(map[*ssa.Function]*funcSummary)
+	a.tracer = &tracer{}
+	a.sinkBlock = block
+	a.labels = sink.Labels
+
+	trace := SinkTrace{Sink: sink, Call: call, Func: fn, Arg: idx}
+	if !a.mayBeTainted(arg) {
+		trace.Skipped = "no source reaches the argument"
+		return trace
+	}
+	a.traced(arg, fn, func() bool { return a.isSinkArgTainted(arg, sink.ArgFields[idx], fn) })
+	trace.Root = a.tracer.root
+	// isSinkArgTainted traces the argument itself first: drop the duplicate.
+	if root := trace.Root; len(root.Children) == 1 && root.Children[0].Value == arg {
+		trace.Root = root.Children[0]
+	}
+	return trace
+}
@@ -0,0 +1,284 @@
+package taint
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// maxSummaryRounds bounds the fixpoint iteration used to propagate sink
+// reachability through calls between functions of the same package.
+const maxSummaryRounds = 8
+
+// FunctionSummary describes how the parameters of a function propagate taint,
+// as seen from its callers.
+type FunctionSummary struct {
+	// SinkParams maps a parameter index to the keys of the sinks it reaches
+	// (e.g. "(*database/sql.DB).Query").
+	SinkParams map[int][]string
+	// ReturnParams lists the parameter indices that flow into a return value.
+	ReturnParams []int
+}
+
+// FunctionFact is an analysis.Fact exported for functions whose parameters
+// reach a sink or a return value, so that the analysis of importing packages
+// can follow flows across package boundaries. Summaries are keyed by rule ID,
+// since drivers which run every gosec rule as a single analyzer share one fact
+// per object.
+type FunctionFact struct {
+	Summaries map[string]FunctionSummary
+}
+
+// AFact implements analysis.Fact.
+func (*FunctionFact) AFact() {}
+
+// MergeFact adds the summaries of rules missing from f, as found in the fact
+// previously exported for the same object. Drivers sharing one fact store
+// between analyzers call it under their lock, so that rules exporting at the
+// same time do not drop each other's summaries.
+func (f *FunctionFact) MergeFact(existing analysis.Fact) {
+	prev, ok := existing.(*FunctionFact)
+	if !ok {
+		return
+	}
+	for id, summary := range prev.Summaries {
+		if _, ok := f.Summaries[id]; !ok {
+			if f.Summaries == nil {
+				f.Summaries = make(map[string]FunctionSummary)
+			}
+			f.Summaries[id] = summary
+		}
+	}
+}
+
+func (f *FunctionFact) String() string {
+	ids := make([]string, 0, len(f.Summaries))
+	for id := range f.Summaries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		s := f.Summaries[id]
+		params := make([]int, 0, len(s.SinkParams))
+		for idx := range s.SinkParams {
+			params = append(params, idx)
+		}
+		sort.Ints(params)
+		var sinks []string
+		for _, idx := range params {
+			sinks = append(sinks, fmt.Sprintf("%d->%s", idx, strings.Join(s.SinkParams[idx], "|")))
+		}
+		parts = append(parts, fmt.Sprintf("%s{sinks:[%s] returns:%v}", id, strings.Join(sinks, " "), s.ReturnParams))
+	}
+	return "taint(" + strings.Join(parts, " ") + ")"
+}
+
+// SetFactImporter injects a lookup for summaries of functions defined in
+// other packages, typically backed by analysis.Pass.ImportObjectFact.
+func (a *Analyzer) SetFactImporter(importer func(fn *ssa.Function) (FunctionSummary, bool)) {
+	a.factImporter = importer
+}
+
+// importedSummary returns the summary of a function without an analyzable
+// body, as provided by the fact importer.
+func (a *Analyzer) importedSummary(callee *ssa.Function) (FunctionSummary, bool) {
+	if a.factImporter == nil || callee == nil || len(callee.Blocks) > 0 {
+		return FunctionSummary{}, false
+	}
+	return a.factImporter(callee)
+}
+
+// importedSinkResult reports a flow when a tainted argument is passed to a
+// parameter that reaches a sink in another package.
+func (a *Analyzer) importedSinkResult(call ssa.CallInstruction, fn *ssa.Function) (Result, bool) {
+	args := call.Common().Args
+	summary, ok := a.importedSummary(call.Common().StaticCallee())
+	if !ok || len(summary.SinkParams) == 0 {
+		return Result{}, false
+	}
+
+	params := make([]int, 0, len(summary.SinkParams))
+	for idx := range summary.SinkParams {
+		params = append(params, idx)
+	}
+	sort.Ints(params)
+
+	for _, idx := range params {
+		if idx >= len(args) {
+			continue
+		}
+		sink := Sink{}
+		if keys := summary.SinkParams[idx]; len(keys) > 0 {
+			sink = a.sinks[keys[0]]
+		}
+		arg := args[idx]
+		a.labels = sink.Labels
+		tainted := a.mayBeTainted(arg) && a.isTainted(arg, fn, make(map[ssa.Value]bool), 0)
+		a.labels = 0
+		if !tainted {
+			continue
+		}
+		return Result{
+			Sink:    sink,
+			SinkPos: call.Pos(),
+			Path:    a.buildPath(fn),
+			Label: a.sourceLabel(sink.Labels, func() bool {
+				return a.isTainted(arg, fn, make(map[ssa.Value]bool), 0)
+			}),
+		}, true
+	}
+	return Result{}, false
+}
+
+// Summaries computes the summaries of the given functions that are worth
+// exporting: parameters reaching a configured sink (directly, through other
+// functions of the package, or through imported summaries) and parameters
+// flowing into return values. Only functions with a types.Object are
+// returned, since facts can only be attached to objects.
+func (a *Analyzer) Summaries(srcFuncs []*ssa.Function) map[*ssa.Function]FunctionSummary {
+	sinkParams := make(map[*ssa.Function]map[int]map[string]bool)
+	a.srcFuncs, a.fieldStores = srcFuncs, nil
+
+	for range maxSummaryRounds {
+		if a.expired() {
+			break
+		}
+		changed := false
+		for _, fn := range srcFuncs {
+			if fn == nil || len(fn.Params) == 0 {
+				continue
+			}
+			for _, block := range fn.Blocks {
+				for _, instr := range block.Instrs {
+					call, ok := instr.(ssa.CallInstruction)
+					if !ok {
+						continue
+					}
+					for _, target := range a.summarySinkTargets(call, sinkParams) {
+						for i, param := range fn.Params {
+							params := map[*ssa.Parameter]bool{param: true}
+							if !a.valueReachableFromParams(target.arg, params, make(map[ssa.Value]bool), 0) {
+								continue
+							}
+							if addSinkKeys(sinkParams, fn, i, target.keys) {
+								changed = true
+							}
+						}
+					}
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	summaries := make(map[*ssa.Function]FunctionSummary)
+	for _, fn := range srcFuncs {
+		if fn == nil || fn.Object() == nil || len(fn.Params) == 0 {
+			continue
+		}
+		summary := FunctionSummary{}
+		for idx, keys := range sinkParams[fn] {
+			if summary.SinkParams == nil {
+				summary.SinkParams = make(map[int][]string)
+			}
+			for key := range keys {
+				summary.SinkParams[idx] = append(summary.SinkParams[idx], key)
+			}
+			sort.Strings(summary.SinkParams[idx])
+		}
+		for i := range fn.Params {
+			if a.paramFlowsToReturn(fn, i) {
+				summary.ReturnParams = append(summary.ReturnParams, i)
+			}
+		}
+		if len(summary.SinkParams) > 0 || len(summary.ReturnParams) > 0 {
+			summaries[fn] = summary
+		}
+	}
+	return summaries
+}
+
+// summaryTarget is a call argument that reaches the sinks identified by keys.
+type summaryTarget struct {
+	arg  ssa.Value
+	keys []string
+}
+
+// summarySinkTargets returns the arguments of call that reach a sink: checked
+// arguments of a configured sink, arguments bound to sink-reaching parameters
+// of functions summarized so far, and arguments of imported summaries.
+func (a *Analyzer) summarySinkTargets(call ssa.CallInstruction, sinkParams map[*ssa.Function]map[int]map[string]bool) []summaryTarget {
+	args := call.Common().Args
+
+	if sink, sinkArgs, isSink := a.sinkCall(call); isSink {
+		args = sinkArgs
+		if !guardsSatisfied(args, sink, a.prog) {
+			return nil
+		}
+		key := []string{formatSinkKey(sink)}
+		var targets []summaryTarget
+		for idx, arg := range args {
+			if len(sink.CheckArgs) > 0 && !slices.Contains(sink.CheckArgs, idx) {
+				continue
+			}
+			targets = append(targets, summaryTarget{arg: arg, keys: key})
+		}
+		return targets
+	}
+
+	callee := call.Common().StaticCallee()
+	if callee == nil {
+		return nil
+	}
+
+	var targets []summaryTarget
+	if params, ok := sinkParams[callee]; ok {
+		for idx, keys := range params {
+			if idx >= len(args) {
+				continue
+			}
+			list := make([]string, 0, len(keys))
+			for key := range keys {
+				list = append(list, key)
+			}
+			targets = append(targets, summaryTarget{arg: args[idx], keys: list})
+		}
+	}
+	if summary, ok := a.importedSummary(callee); ok {
+		for idx, keys := range summary.SinkParams {
+			if idx < len(args) {
+				targets = append(targets, summaryTarget{arg: args[idx], keys: keys})
+			}
+		}
+	}
+	return targets
+}
+
+func addSinkKeys(sinkParams map[*ssa.Function]map[int]map[string]bool, fn *ssa.Function, idx int, keys []string) bool {
+	params, ok := sinkParams[fn]
+	if !ok {
+		params = make(map[int]map[string]bool)
+		sinkParams[fn] = params
+	}
+	set, ok := params[idx]
+	if !ok {
+		set = make(map[string]bool)
+		params[idx] = set
+	}
+	added := false
+	for _, key := range keys {
+		if !set[key] {
+			set[key] = true
+			added = true
+		}
+	}
+	return added
+}
@@ -0,0 +1,339 @@
+package taint
+
+import (
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// isGuardedAtSink reports whether v is validated by a guard check whose
+// passing branch dominates the block of the sink currently being checked.
+// Guard checks are guard and validator sanitizers as well as, for rules
+// enabling AllowlistMaps, map-membership (allowlist) lookups keyed by v.
+//
+// Example:
+//
+//	name := r.URL.Query().Get("file")
+//	if !filepath.IsLocal(name) {
+//		return
+//	}
+//	os.Open(filepath.Join(dir, name)) // name is clean here
+//
+//	if err := validate(id); err != nil {
+//		return
+//	}
+//	db.Query("SELECT ... " + id) // id is clean here
+//
+//	if !allowed[cmd] {
+//		return
+//	}
+//	exec.Command(cmd) // cmd is clean here
+func (a *Analyzer) isGuardedAtSink(v ssa.Value) bool {
+	if a.sinkBlock == nil {
+		return false
+	}
+	if v.Parent() != a.sinkBlock.Parent() {
+		return false
+	}
+
+	for _, candidate := range equivalentLoads(v) {
+		for _, ref := range safeRefs(candidate) {
+			for _, passing := range a.guardPassingBlocks(ref, candidate) {
+				if passing.Dominates(a.sinkBlock) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// guardPassingBlocks returns the blocks entered when the guard check performed
+// by instr on v succeeds, or nil if instr is not a guard check on v.
+func (a *Analyzer) guardPassingBlocks(instr ssa.Instruction, v ssa.Value) []*ssa.BasicBlock {
+	switch val := instr.(type) {
+	case *ssa.Call:
+		if !slices.Contains(val.Call.Args, v) {
+			return nil
+		}
+		san, ok := a.guardSanitizer(val)
+		if !ok {
+			return nil
+		}
+		if san.Guard {
+			return passingBlocks(val)
+		}
+		return nilErrorBlocks(val)
+	case *ssa.Lookup:
+		if val.Index != v {
+			return nil
+		}
+		if _, isMap := val.X.Type().Underlying().(*types.Map); !isMap {
+			return nil
+		}
+		if !a.config.AllowlistMaps || !a.isAllowlistMap(val.X) {
+			return nil
+		}
+		// _, ok := allowed[v]; if ok { ... }
+		if val.CommaOk {
+			var blocks []*ssa.BasicBlock
+			for _, ref := range safeRefs(val) {
+				if extract, ok := ref.(*ssa.Extract); ok && extract.Index == 1 {
+					blocks = append(blocks, passingBlocks(extract)...)
+				}
+			}
+			return blocks
+		}
+		// if allowed[v] { ... } with a map[T]bool
+		if basic, ok := val.Type().Underlying().(*types.Basic); ok && basic.Kind() == types.Bool {
+			return passingBlocks(val)
+		}
+	}
+	return nil
+}
+
+// isAllowlistMap reports whether m is a local map or a variable of the
+// analyzed package whose writes can all be found, none of them with a tainted
+// key. Maps filled from untrusted input (e.g. a set of seen values) or handed
+// to other functions are not allowlists.
+func (a *Analyzer) isAllowlistMap(m ssa.Value) bool {
+	var maps []ssa.Value
+	var global *ssa.Global
+	switch val := m.(type) {
+	case *ssa.MakeMap:
+		maps = append(maps, val)
+	case *ssa.UnOp:
+		g, ok := val.X.(*ssa.Global)
+		if !ok || val.Op != token.MUL || len(a.srcFuncs) == 0 || a.srcFuncs[0] == nil || g.Pkg != a.srcFuncs[0].Pkg {
+			return false
+		}
+		global = g
+		// Every load of the variable, and every map stored into it
+		for _, instr := range a.globalUseIndex()[global] {
+			switch instr := instr.(type) {
+			case *ssa.UnOp:
+				maps = append(maps, instr)
+			case *ssa.Store:
+				if instr.Addr != global {
+					return false
+				}
+				maps = append(maps, instr.Val)
+			default:
+				// The address of the variable escapes
+				return false
+			}
+		}
+		if len(maps) == 0 {
+			return false
+		}
+	default:
+		return false
+	}
+
+	// Guards of the sink do not hold where the map is written, and checking
+	// them again would loop on the lookup being evaluated.
+	defer func(saved *ssa.BasicBlock) { a.sinkBlock = saved }(a.sinkBlock)
+	a.sinkBlock = nil
+	for _, mv := range maps {
+		for _, ref := range safeRefs(mv) {
+			switch instr := ref.(type) {
+			case *ssa.MapUpdate:
+				if instr.Map == mv && a.isTainted(instr.Key, instr.Parent(), make(map[ssa.Value]bool), 0) {
+					return false
+				}
+			case *ssa.Lookup, *ssa.Range, *ssa.DebugRef:
+			case *ssa.Store:
+				if global == nil || instr.Addr != global {
+					return false
+				}
+			case *ssa.Call:
+				if _, isBuiltin := instr.Call.Value.(*ssa.Builtin); !isBuiltin {
+					return false
+				}
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// globalUseIndex returns the instructions of the analyzed functions, and of
+// the initializer of their package, that use package variables, built on
+// first use.
+func (a *Analyzer) globalUseIndex() map[*ssa.Global][]ssa.Instruction {
+	if a.globalUses != nil {
+		return a.globalUses
+	}
+	a.globalUses = make(map[*ssa.Global][]ssa.Instruction)
+	funcs := slices.Clone(a.srcFuncs)
+	if len(funcs) > 0 && funcs[0] != nil && funcs[0].Pkg != nil {
+		if init := funcs[0].Pkg.Func("init"); init != nil && !slices.Contains(funcs, init) {
+			funcs = append(funcs, init)
+		}
+	}
+	var operands []*ssa.Value
+	for _, fn := range funcs {
+		if fn == nil {
+			continue
+		}
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				operands = instr.Operands(operands[:0])
+				for _, op := range operands {
+					if op == nil {
+						continue
+					}
+					if global, ok := (*op).(*ssa.Global); ok {
+						a.globalUses[global] = append(a.globalUses[global], instr)
+					}
+				}
+			}
+		}
+	}
+	return a.globalUses
+}
+
+// equivalentLoads returns v together with other loads of the same address,
+// since each read of a local variable that escapes to the heap produces a
+// distinct SSA value. Loads that can be separated from v by a store to the
+// address read another value and are left out.
+func equivalentLoads(v ssa.Value) []ssa.Value {
+	values := []ssa.Value{v}
+	load, ok := v.(*ssa.UnOp)
+	if !ok || load.Op != token.MUL {
+		return values
+	}
+	var stores []*ssa.Store
+	for _, ref := range safeRefs(load.X) {
+		if store, ok := ref.(*ssa.Store); ok && store.Addr == load.X {
+			stores = append(stores, store)
+		}
+	}
+	for _, ref := range safeRefs(load.X) {
+		other, ok := ref.(*ssa.UnOp)
+		if !ok || other == load || other.Op != token.MUL {
+			continue
+		}
+		if !slices.ContainsFunc(stores, func(store *ssa.Store) bool {
+			return reaches(other, store) && reaches(store, load)
+		}) {
+			values = append(values, other)
+		}
+	}
+	return values
+}
+
+// reaches reports whether to can execute after from in the same call of
+// their function.
+func reaches(from, to ssa.Instruction) bool {
+	if from.Block() == to.Block() && instrIndex(from) < instrIndex(to) {
+		return true
+	}
+	seen := make(map[*ssa.BasicBlock]bool)
+	stack := slices.Clone(from.Block().Succs)
+	for len(stack) > 0 {
+		block := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if block == to.Block() {
+			return true
+		}
+		if seen[block] {
+			continue
+		}
+		seen[block] = true
+		stack = append(stack, block.Succs...)
+	}
+	return false
+}
+
+// instrIndex returns the position of instr in its block.
+func instrIndex(instr ssa.Instruction) int {
+	return slices.Index(instr.Block().Instrs, instr)
+}
+
+// passingBlocks returns the successor blocks entered when the boolean result
+// of cond evaluates to true, following negations. Successors that can also be
+// entered from elsewhere (e.g. short-circuit ||) are not considered.
+func passingBlocks(cond ssa.Value) []*ssa.BasicBlock {
+	var blocks []*ssa.BasicBlock
+	for _, ref := range safeRefs(cond) {
+		switch instr := ref.(type) {
+		case *ssa.If:
+			blocks = appendSuccessor(blocks, instr, 0)
+		case *ssa.UnOp:
+			if instr.Op != token.NOT {
+				continue
+			}
+			for _, negRef := range safeRefs(instr) {
+				if ifInstr, ok := negRef.(*ssa.If); ok {
+					blocks = appendSuccessor(blocks, ifInstr, 1)
+				}
+			}
+		}
+	}
+	return blocks
+}
+
+// nilErrorBlocks returns the successor blocks entered when the error returned
+// by call (its sole or last result) is nil.
+func nilErrorBlocks(call *ssa.Call) []*ssa.BasicBlock {
+	errVal := ssa.Value(call)
+	if tuple, ok := call.Type().(*types.Tuple); ok {
+		errVal = nil
+		for _, ref := range safeRefs(call) {
+			if extract, ok := ref.(*ssa.Extract); ok && extract.Index == tuple.Len()-1 {
+				errVal = extract
+			}
+		}
+		if errVal == nil {
+			return nil
+		}
+	}
+
+	var blocks []*ssa.BasicBlock
+	for _, ref := range safeRefs(errVal) {
+		cmp, ok := ref.(*ssa.BinOp)
+		if !ok || (!isNilConst(cmp.X) && !isNilConst(cmp.Y)) {
+			continue
+		}
+		for _, cmpRef := range safeRefs(cmp) {
+			ifInstr, ok := cmpRef.(*ssa.If)
+			if !ok {
+				continue
+			}
+			switch cmp.Op {
+			case token.EQL: // if err == nil { ... }
+				blocks = appendSuccessor(blocks, ifInstr, 0)
+			case token.NEQ: // if err != nil { return }
+				blocks = appendSuccessor(blocks, ifInstr, 1)
+			}
+		}
+	}
+	return blocks
+}
+
+// appendSuccessor appends the idx-th successor of the If block when it can
+// only be entered through that branch.
+func appendSuccessor(blocks []*ssa.BasicBlock, ifInstr *ssa.If, idx int) []*ssa.BasicBlock {
+	succs := ifInstr.Block().Succs
+	if len(succs) == 2 && len(succs[idx].Preds) == 1 {
+		blocks = append(blocks, succs[idx])
+	}
+	return blocks
+}
+
+func isNilConst(v ssa.Value) bool {
+	c, ok := v.(*ssa.Const)
+	return ok && c.IsNil()
+}
+
+func safeRefs(v ssa.Value) []ssa.Instruction {
+	refs := v.Referrers()
+	if refs == nil {
+		return nil
+	}
+	return *refs
+}
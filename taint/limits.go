@@ -0,0 +1,71 @@
+package taint
+
+import (
+	"sort"
+	"time"
+)
+
+// Limits bounds the work of the taint engine. Zero fields select the defaults.
+type Limits struct {
+	// MaxDepth bounds the values explored back from a sink argument.
+	MaxDepth int
+	// MaxCallerEdges bounds the callers explored for each tainted parameter.
+	MaxCallerEdges int
+	// Deadline stops the analysis of the remaining functions once passed.
+	Deadline time.Time
+}
+
+// SetLimits replaces the default limits of the analyzer.
+func (a *Analyzer) SetLimits(limits Limits) {
+	a.limits = limits
+}
+
+// Truncated returns the limits reached since the last call of Analyze, as the
+// cutoffs recorded when they were: CutoffDepth, CutoffCallerEdges and
+// CutoffDeadline. Results may be missing when it is not empty.
+func (a *Analyzer) Truncated() []Cutoff {
+	cutoffs := make([]Cutoff, 0, len(a.truncated))
+	for cutoff := range a.truncated {
+		cutoffs = append(cutoffs, cutoff)
+	}
+	sort.Slice(cutoffs, func(i, j int) bool { return cutoffs[i] < cutoffs[j] })
+	return cutoffs
+}
+
+func (a *Analyzer) truncate(reason Cutoff) {
+	if a.truncated == nil {
+		a.truncated = make(map[Cutoff]bool)
+	}
+	a.truncated[reason] = true
+}
+
+// beyondDepth reports whether depth exceeds the depth limit, recording the
+// truncation when it does.
+func (a *Analyzer) beyondDepth(depth int) bool {
+	limit := a.limits.MaxDepth
+	if limit <= 0 {
+		limit = maxTaintDepth
+	}
+	if depth <= limit {
+		return false
+	}
+	a.truncate(CutoffDepth)
+	return true
+}
+
+func (a *Analyzer) callerEdgeLimit() int {
+	if a.limits.MaxCallerEdges > 0 {
+		return a.limits.MaxCallerEdges
+	}
+	return maxCallerEdges
+}
+
+// expired reports whether the deadline passed, recording the truncation when
+// it did.
+func (a *Analyzer) expired() bool {
+	if a.limits.Deadline.IsZero() || time.Now().Before(a.limits.Deadline) {
+		return false
+	}
+	a.truncate(CutoffDeadline)
+	return true
+}
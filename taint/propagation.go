@@ -0,0 +1,324 @@
+package taint
+
+import (
+	"go/token"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// propagation models how taint moves through a function whose body is not
+// analyzed (standard library, builtins).
+type propagation struct {
+	// toResult lists the argument indices (the receiver is index 0 for static
+	// method calls) whose taint reaches the call result.
+	toResult []int
+	// into is the index of an argument that receives the data of the
+	// remaining arguments (e.g. the receiver of (*strings.Builder).WriteString
+	// or the dst of copy), or -1 when the call writes into no argument.
+	into int
+}
+
+// resultFrom builds a propagation where the listed arguments flow into the result.
+func resultFrom(args ...int) propagation {
+	return propagation{toResult: args, into: -1}
+}
+
+// writesInto builds a propagation where the other arguments flow into argument idx.
+func writesInto(idx int) propagation {
+	return propagation{into: idx}
+}
+
+// noPropagation marks functions whose result does not carry argument data
+// (predicates, lengths, comparisons).
+var noPropagation = propagation{into: -1}
+
+// builtinPropagations models Go builtins, keyed by builtin name.
+var builtinPropagations = map[string]propagation{
+	"append": resultFrom(0, 1),
+	"copy":   writesInto(0),
+	"len":    noPropagation,
+	"cap":    noPropagation,
+}
+
+// stdlibPropagations models common standard library transformers, keyed like
+// sanitizers ("pkg.Func" or "(*pkg.Type).Method"). Functions absent from the
+// table without an analyzable body conservatively propagate every argument.
+var stdlibPropagations = map[string]propagation{
+	// strings
+	"strings.Clone":                  resultFrom(0),
+	"strings.Fields":                 resultFrom(0),
+	"strings.Join":                   resultFrom(0, 1),
+	"strings.Repeat":                 resultFrom(0),
+	"strings.Replace":                resultFrom(0, 2),
+	"strings.ReplaceAll":             resultFrom(0, 2),
+	"strings.Split":                  resultFrom(0),
+	"strings.SplitN":                 resultFrom(0),
+	"strings.SplitAfter":             resultFrom(0),
+	"strings.SplitAfterN":            resultFrom(0),
+	"strings.Cut":                    resultFrom(0),
+	"strings.CutPrefix":              resultFrom(0),
+	"strings.CutSuffix":              resultFrom(0),
+	"strings.ToLower":                resultFrom(0),
+	"strings.ToUpper":                resultFrom(0),
+	"strings.ToTitle":                resultFrom(0),
+	"strings.Trim":                   resultFrom(0),
+	"strings.TrimLeft":               resultFrom(0),
+	"strings.TrimRight":              resultFrom(0),
+	"strings.TrimPrefix":             resultFrom(0),
+	"strings.TrimSuffix":             resultFrom(0),
+	"strings.TrimSpace":              resultFrom(0),
+	"strings.TrimFunc":               resultFrom(0),
+	"strings.Contains":               noPropagation,
+	"strings.ContainsAny":            noPropagation,
+	"strings.ContainsRune":           noPropagation,
+	"strings.HasPrefix":              noPropagation,
+	"strings.HasSuffix":              noPropagation,
+	"strings.EqualFold":              noPropagation,
+	"strings.Compare":                noPropagation,
+	"strings.NewReader":              resultFrom(0),
+	"strings.NewReplacer":            resultFrom(0),
+	"(*strings.Builder).String":      resultFrom(0),
+	"(*strings.Builder).Write":       writesInto(0),
+	"(*strings.Builder).WriteString": writesInto(0),
+	"(*strings.Builder).WriteByte":   writesInto(0),
+	"(*strings.Builder).WriteRune":   writesInto(0),
+	"(*strings.Builder).Len":         noPropagation,
+	"(*strings.Replacer).Replace":    resultFrom(0, 1),
+
+	// bytes
+	"bytes.Clone":                 resultFrom(0),
+	"bytes.Fields":                resultFrom(0),
+	"bytes.Join":                  resultFrom(0, 1),
+	"bytes.Repeat":                resultFrom(0),
+	"bytes.Replace":               resultFrom(0, 2),
+	"bytes.ReplaceAll":            resultFrom(0, 2),
+	"bytes.Split":                 resultFrom(0),
+	"bytes.SplitN":                resultFrom(0),
+	"bytes.Cut":                   resultFrom(0),
+	"bytes.ToLower":               resultFrom(0),
+	"bytes.ToUpper":               resultFrom(0),
+	"bytes.Trim":                  resultFrom(0),
+	"bytes.TrimPrefix":            resultFrom(0),
+	"bytes.TrimSuffix":            resultFrom(0),
+	"bytes.TrimSpace":             resultFrom(0),
+	"bytes.Contains":              noPropagation,
+	"bytes.HasPrefix":             noPropagation,
+	"bytes.HasSuffix":             noPropagation,
+	"bytes.Equal":                 noPropagation,
+	"bytes.EqualFold":             noPropagation,
+	"bytes.Compare":               noPropagation,
+	"bytes.NewBuffer":             resultFrom(0),
+	"bytes.NewBufferString":       resultFrom(0),
+	"bytes.NewReader":             resultFrom(0),
+	"(*bytes.Buffer).String":      resultFrom(0),
+	"(*bytes.Buffer).Bytes":       resultFrom(0),
+	"(*bytes.Buffer).Write":       writesInto(0),
+	"(*bytes.Buffer).WriteString": writesInto(0),
+	"(*bytes.Buffer).WriteByte":   writesInto(0),
+	"(*bytes.Buffer).WriteRune":   writesInto(0),
+	"(*bytes.Buffer).ReadFrom":    writesInto(0),
+	"(*bytes.Buffer).Len":         noPropagation,
+
+	// fmt
+	"fmt.Sprintf":  resultFrom(0, 1),
+	"fmt.Sprint":   resultFrom(0),
+	"fmt.Sprintln": resultFrom(0),
+	"fmt.Errorf":   resultFrom(0, 1),
+	"fmt.Appendf":  resultFrom(0, 1, 2),
+	"fmt.Fprintf":  writesInto(0),
+	"fmt.Fprint":   writesInto(0),
+	"fmt.Fprintln": writesInto(0),
+
+	// path, path/filepath
+	"path.Join":               resultFrom(0),
+	"path.Base":               resultFrom(0),
+	"path.Dir":                resultFrom(0),
+	"path.Ext":                resultFrom(0),
+	"path.Clean":              resultFrom(0),
+	"path/filepath.Join":      resultFrom(0),
+	"path/filepath.Base":      resultFrom(0),
+	"path/filepath.Dir":       resultFrom(0),
+	"path/filepath.Ext":       resultFrom(0),
+	"path/filepath.Abs":       resultFrom(0),
+	"path/filepath.ToSlash":   resultFrom(0),
+	"path/filepath.FromSlash": resultFrom(0),
+
+	// io
+	"io.ReadAll":     resultFrom(0),
+	"io.Copy":        writesInto(0),
+	"io.CopyN":       writesInto(0),
+	"io.CopyBuffer":  writesInto(0),
+	"io.WriteString": writesInto(0),
+}
+
+// propagationFor returns the propagation model for a call, if one is known.
+func propagationFor(call *ssa.CallCommon) (propagation, bool) {
+	if builtin, ok := call.Value.(*ssa.Builtin); ok {
+		prop, found := builtinPropagations[builtin.Name()]
+		return prop, found
+	}
+	key, ok := sanitizerKeyForCall(call)
+	if !ok {
+		return propagation{}, false
+	}
+	prop, found := stdlibPropagations[key]
+	return prop, found
+}
+
+// isResultTaintedByModel checks the arguments that the propagation model
+// routes into the call result.
+func (a *Analyzer) isResultTaintedByModel(call *ssa.Call, prop propagation, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	args := call.Call.Args
+	for _, idx := range prop.toResult {
+		if idx >= len(args) {
+			continue
+		}
+		if a.isTainted(args[idx], fn, visited, depth) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWrittenWithTaint reports whether v (typically a local strings.Builder,
+// bytes.Buffer or destination slice) is passed as the written-to argument of
+// a modelled call whose other arguments are tainted.
+//
+// Example:
+//
+//	var b strings.Builder
+//	b.WriteString(r.FormValue("q"))
+//	db.Query(b.String()) // b is tainted
+func (a *Analyzer) isWrittenWithTaint(v ssa.Value, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	for _, ref := range safeRefs(v) {
+		switch instr := ref.(type) {
+		case *ssa.Call:
+			prop, ok := propagationFor(&instr.Call)
+			if !ok || prop.into < 0 || prop.into >= len(instr.Call.Args) || instr.Call.Args[prop.into] != v {
+				continue
+			}
+			for i, arg := range instr.Call.Args {
+				if i == prop.into || isContextType(arg.Type()) {
+					continue
+				}
+				if a.isTainted(arg, fn, visited, depth) {
+					return true
+				}
+			}
+		case *ssa.MakeInterface:
+			// fmt.Fprintf(&buf, ...) passes the buffer as an io.Writer
+			if a.isWrittenWithTaint(instr, fn, visited, depth) {
+				return true
+			}
+		case *ssa.ChangeType:
+			if a.isWrittenWithTaint(instr, fn, visited, depth) {
+				return true
+			}
+		case *ssa.Slice:
+			// copy(buf[:n], src) writes into the backing array
+			if a.isWrittenWithTaint(instr, fn, visited, depth) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isReceivedTainted checks the values sent on a channel created in the
+// analyzed code, including sends performed by closures and goroutines that
+// capture the channel.
+//
+// Example:
+//
+//	ch := make(chan string)
+//	go func() { ch <- r.FormValue("q") }()
+//	exec.Command(<-ch) // received value is tainted
+func (a *Analyzer) isReceivedTainted(recv *ssa.UnOp, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	var origin ssa.Value
+	switch ch := recv.X.(type) {
+	case *ssa.MakeChan:
+		origin = ch
+	case *ssa.UnOp:
+		// Channel captured by a closure lives in a heap cell.
+		if alloc, ok := ch.X.(*ssa.Alloc); ok && ch.Op == token.MUL {
+			origin = alloc
+		}
+	}
+	if origin == nil {
+		// Channel of unknown origin (parameter, field, ...): fall back to the
+		// taint of the channel value itself.
+		return a.isTainted(recv.X, fn, visited, depth)
+	}
+	for _, send := range channelSends(origin) {
+		if a.isTainted(send.X, send.Parent(), visited, depth) {
+			return true
+		}
+	}
+	return false
+}
+
+// channelSends collects the sends on ch (a channel value or the heap cell
+// holding it) within its function and in closures that capture it.
+func channelSends(ch ssa.Value) []*ssa.Send {
+	var sends []*ssa.Send
+	seen := make(map[ssa.Value]bool)
+	var walk func(v ssa.Value)
+	walk = func(v ssa.Value) {
+		if seen[v] {
+			return
+		}
+		seen[v] = true
+		for _, ref := range safeRefs(v) {
+			switch instr := ref.(type) {
+			case *ssa.Send:
+				if instr.Chan == v {
+					sends = append(sends, instr)
+				}
+			case *ssa.MakeClosure:
+				closure, ok := instr.Fn.(*ssa.Function)
+				if !ok {
+					continue
+				}
+				for i, binding := range instr.Bindings {
+					if binding == v && i < len(closure.FreeVars) {
+						walk(closure.FreeVars[i])
+					}
+				}
+			case *ssa.UnOp:
+				if instr.Op == token.MUL {
+					walk(instr)
+				}
+			case *ssa.ChangeType:
+				walk(instr)
+			}
+		}
+	}
+	walk(ch)
+	return sends
+}
+
+// isMapEntryTainted checks the values (and keys, when withKeys is set for
+// range loops) stored into a map created in the analyzed function when one of
+// its entries is read.
+//
+// Example:
+//
+//	m := make(map[string]string)
+//	m["q"] = r.FormValue("q")
+//	db.Query(m["q"]) // lookup is tainted
+func (a *Analyzer) isMapEntryTainted(m ssa.Value, withKeys bool, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	mm, ok := m.(*ssa.MakeMap)
+	if !ok {
+		return a.isTainted(m, fn, visited, depth)
+	}
+	for _, ref := range safeRefs(mm) {
+		update, ok := ref.(*ssa.MapUpdate)
+		if !ok || update.Map != mm {
+			continue
+		}
+		if a.isTainted(update.Value, fn, visited, depth) || (withKeys && a.isTainted(update.Key, fn, visited, depth)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,392 @@
+package taint
+
+import (
+	"go/token"
+	"go/types"
+	"sync"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/securego/gosec/v2/internal/ssautil"
+)
+
+// SourcePass is a forward propagation of the labels of DefaultSources over a
+// package, computed once and shared by every taint rule.
+//
+// The pass ignores sanitizers and guards, and follows every data flow the
+// backward analysis of a rule can follow (and more), so the labels it assigns
+// to a value are a superset of those any rule can prove. A rule therefore only
+// traces the sink arguments reached by one of the labels of the sink: the
+// others are clean for every rule and are skipped.
+type SourcePass struct {
+	once      sync.Once
+	prog      *ssa.Program
+	callGraph *callgraph.Graph
+	srcFuncs  []*ssa.Function
+	sources   *Analyzer
+
+	labels    map[ssa.Value]Label
+	worklist  []ssa.Value
+	callees   map[ssa.CallInstruction][]*ssa.Function
+	callSites map[*ssa.Function][]ssa.CallInstruction
+	uses      map[*ssa.Global][]ssa.Instruction
+}
+
+// sourcePassKey identifies the SourcePass in a ssautil.PackageAnalysisCache.
+type sourcePassKey struct{}
+
+// NewSourcePass creates the source pass of prog. The propagation runs on first
+// use; when cg is nil a CHA call graph is built at that time. srcFuncs are the
+// functions of the analyzed package: they are seeded even when the call graph
+// does not reach them (e.g. methods of types never converted to interfaces).
+func NewSourcePass(prog *ssa.Program, cg *callgraph.Graph, srcFuncs []*ssa.Function) *SourcePass {
+	return &SourcePass{
+		prog:      prog,
+		callGraph: cg,
+		srcFuncs:  srcFuncs,
+		sources:   New(&Config{Sources: DefaultSources}),
+	}
+}
+
+// sharedSourcePass returns the source pass of the package, creating it on first use.
+func sharedSourcePass(cache *ssautil.PackageAnalysisCache, srcFuncs []*ssa.Function) *SourcePass {
+	pass := cache.Value(sourcePassKey{}, func() any {
+		return NewSourcePass(srcFuncs[0].Prog, cache.CallGraph(), srcFuncs)
+	})
+	return pass.(*SourcePass)
+}
+
+// Labels returns the labels of the sources that may reach v.
+// It is safe for concurrent use.
+func (p *SourcePass) Labels(v ssa.Value) Label {
+	p.once.Do(p.propagate)
+	return p.labels[v]
+}
+
+// MayBeTainted reports whether v may be reached by a source relevant to a sink
+// declaring labels (zero for every source).
+func (p *SourcePass) MayBeTainted(v ssa.Value, labels Label) bool {
+	found := p.Labels(v)
+	return found != 0 && labels.matches(found)
+}
+
+func (p *SourcePass) propagate() {
+	p.labels = make(map[ssa.Value]Label)
+	p.callees = make(map[ssa.CallInstruction][]*ssa.Function)
+	p.callSites = make(map[*ssa.Function][]ssa.CallInstruction)
+	p.uses = make(map[*ssa.Global][]ssa.Instruction)
+
+	if p.callGraph == nil {
+		p.callGraph = cha.CallGraph(p.prog)
+	}
+	var funcs []*ssa.Function
+	for _, fn := range p.srcFuncs {
+		if p.callGraph.Nodes[fn] == nil && len(fn.Blocks) > 0 {
+			funcs = append(funcs, fn)
+		}
+	}
+	for fn, node := range p.callGraph.Nodes {
+		if fn == nil || len(fn.Blocks) == 0 {
+			continue
+		}
+		funcs = append(funcs, fn)
+		for _, edge := range node.Out {
+			if edge.Site != nil {
+				p.callees[edge.Site] = append(p.callees[edge.Site], edge.Callee.Func)
+				p.callSites[edge.Callee.Func] = append(p.callSites[edge.Callee.Func], edge.Site)
+			}
+		}
+	}
+
+	for _, fn := range funcs {
+		p.seed(fn)
+	}
+	for len(p.worklist) > 0 {
+		v := p.worklist[len(p.worklist)-1]
+		p.worklist = p.worklist[:len(p.worklist)-1]
+		p.flow(v, p.labels[v])
+	}
+	p.worklist = nil
+}
+
+// seed labels the source-typed parameters, source function calls, scanned
+// destinations and source globals of fn.
+func (p *SourcePass) seed(fn *ssa.Function) {
+	for _, param := range fn.Params {
+		p.add(param, p.typeLabel(param.Type()))
+	}
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			if call, ok := instr.(*ssa.Call); ok {
+				if src, found := p.sources.sourceFunc(&call.Call); found {
+					if src.Scan {
+						for _, dst := range scanDestinations(&call.Call) {
+							p.addRoot(dst, src.Label)
+						}
+					} else {
+						p.add(call, src.Label)
+					}
+				}
+				if src, found := p.sources.returnedSource(call.Type()); found {
+					p.add(call, src.Label)
+				}
+			}
+			var operands [8]*ssa.Value
+			for _, op := range instr.Operands(operands[:0]) {
+				global, ok := (*op).(*ssa.Global)
+				if !ok || global.Pkg == nil || global.Pkg.Pkg == nil {
+					continue
+				}
+				p.uses[global] = append(p.uses[global], instr)
+				if src, found := p.sources.sources[global.Pkg.Pkg.Path()+"."+global.Name()]; found {
+					p.add(global, src.Label)
+				}
+			}
+		}
+	}
+}
+
+// flow propagates the labels of v to the values derived from it.
+func (p *SourcePass) flow(v ssa.Value, labels Label) {
+	var refs []ssa.Instruction
+	if global, ok := v.(*ssa.Global); ok {
+		refs = p.uses[global]
+	} else if r := v.Referrers(); r != nil {
+		refs = *r
+	}
+
+	for _, ref := range refs {
+		switch instr := ref.(type) {
+		case *ssa.Store:
+			if instr.Val == v {
+				p.addRoot(instr.Addr, labels)
+			}
+		case *ssa.MapUpdate:
+			if instr.Key == v || instr.Value == v {
+				p.addRoot(instr.Map, labels)
+			}
+		case *ssa.Send:
+			if instr.X == v {
+				p.addRoot(instr.Chan, labels)
+			}
+		case *ssa.Return:
+			for _, site := range p.callSites[instr.Parent()] {
+				if result := site.Value(); result != nil {
+					p.add(result, labels)
+				}
+			}
+		case *ssa.MakeClosure:
+			p.add(instr, labels)
+			if closure, ok := instr.Fn.(*ssa.Function); ok {
+				for i, binding := range instr.Bindings {
+					if binding == v && i < len(closure.FreeVars) {
+						p.add(closure.FreeVars[i], labels)
+					}
+				}
+			}
+		case ssa.CallInstruction:
+			p.flowCall(instr, v, labels)
+		case ssa.Value:
+			p.add(instr, labels)
+		}
+	}
+}
+
+// flowCall propagates the labels of v, an operand of call, to the call result,
+// to the arguments the call writes into and to the parameters of the callees.
+func (p *SourcePass) flowCall(call ssa.CallInstruction, v ssa.Value, labels Label) {
+	common := call.Common()
+	if result := call.Value(); result != nil {
+		p.add(result, labels)
+	}
+	if prop, ok := propagationFor(common); ok && prop.into >= 0 && prop.into < len(common.Args) {
+		p.addRoot(common.Args[prop.into], labels)
+	}
+	for _, arg := range common.Args {
+		if slice, ok := arg.(*ssa.MakeSlice); ok {
+			p.add(slice, labels)
+		}
+	}
+
+	for _, callee := range p.callees[call] {
+		if common.IsInvoke() && common.Value == v && len(callee.Params) > 0 {
+			p.add(callee.Params[0], labels)
+		}
+		for i, arg := range common.Args {
+			if arg != v {
+				continue
+			}
+			// The receiver of an invoked method is not part of Args, so the
+			// argument is bound to the next parameter; the backward analysis
+			// maps indices without this shift, hence both are labelled.
+			for _, idx := range []int{i, i + 1} {
+				if idx < len(callee.Params) && (idx == i || common.IsInvoke()) {
+					p.add(callee.Params[idx], labels)
+				}
+			}
+		}
+	}
+}
+
+// addRoot labels v and the values it addresses: the struct of a field, the
+// slice of an element, the cell of a loaded pointer and the variables bound to
+// a closure's free variable.
+func (p *SourcePass) addRoot(v ssa.Value, labels Label) {
+	for v != nil {
+		p.add(v, labels)
+		switch val := v.(type) {
+		case *ssa.FieldAddr:
+			v = val.X
+		case *ssa.IndexAddr:
+			v = val.X
+		case *ssa.Slice:
+			v = val.X
+		case *ssa.MakeInterface:
+			v = val.X
+		case *ssa.ChangeType:
+			v = val.X
+		case *ssa.ChangeInterface:
+			v = val.X
+		case *ssa.UnOp:
+			if val.Op != token.MUL {
+				return
+			}
+			v = val.X
+		case *ssa.FreeVar:
+			for _, binding := range closureBindings(val) {
+				p.addRoot(binding, labels)
+			}
+			return
+		default:
+			return
+		}
+	}
+}
+
+func (p *SourcePass) add(v ssa.Value, labels Label) {
+	if labels == 0 {
+		return
+	}
+	if old := p.labels[v]; old|labels != old {
+		p.labels[v] = old | labels
+		p.worklist = append(p.worklist, v)
+	}
+}
+
+// typeLabel returns the label of the source type matching t, if any.
+func (p *SourcePass) typeLabel(t types.Type) Label {
+	if ptr, ok := t.(*types.Pointer); ok {
+		if label := p.typeLabel(ptr.Elem()); label != 0 {
+			return label
+		}
+	}
+	if src, ok := p.sources.sources[t.String()]; ok {
+		return src.Label
+	}
+	if named, ok := t.(*types.Named); ok && named.Obj() != nil && named.Obj().Pkg() != nil {
+		key := named.Obj().Pkg().Path() + "." + named.Obj().Name()
+		if src, ok := p.sources.sources[key]; ok {
+			return src.Label
+		}
+		if src, ok := p.sources.sources["*"+key]; ok {
+			return src.Label
+		}
+	}
+	return 0
+}
+
+// scanDestinations returns the pointers a call writes into: its pointer
+// arguments, including those passed through a variadic ...any slice.
+func scanDestinations(call *ssa.CallCommon) []ssa.Value {
+	var dsts []ssa.Value
+	var collect func(v ssa.Value)
+	collect = func(v ssa.Value) {
+		switch val := v.(type) {
+		case *ssa.MakeInterface:
+			collect(val.X)
+		case *ssa.ChangeType:
+			collect(val.X)
+		case *ssa.Slice:
+			array, ok := val.X.(*ssa.Alloc)
+			if !ok || array.Referrers() == nil {
+				return
+			}
+			for _, ref := range *array.Referrers() {
+				elem, ok := ref.(*ssa.IndexAddr)
+				if !ok || elem.Referrers() == nil {
+					continue
+				}
+				for _, elemRef := range *elem.Referrers() {
+					if store, ok := elemRef.(*ssa.Store); ok && store.Addr == elem {
+						collect(store.Val)
+					}
+				}
+			}
+		default:
+			if _, ok := v.Type().Underlying().(*types.Pointer); ok {
+				dsts = append(dsts, v)
+			}
+		}
+	}
+	args := call.Args
+	if call.Signature().Recv() != nil && len(args) > 0 {
+		args = args[1:]
+	}
+	for _, arg := range args {
+		collect(arg)
+	}
+	return dsts
+}
+
+// closureBindings returns the values bound to fv by the closures creating its function.
+func closureBindings(fv *ssa.FreeVar) []ssa.Value {
+	fn := fv.Parent()
+	idx := -1
+	for i, v := range fn.FreeVars {
+		if v == fv {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 || fn.Parent() == nil {
+		return nil
+	}
+	var bindings []ssa.Value
+	for _, block := range fn.Parent().Blocks {
+		for _, instr := range block.Instrs {
+			if mc, ok := instr.(*ssa.MakeClosure); ok && mc.Fn == fn && idx < len(mc.Bindings) {
+				bindings = append(bindings, mc.Bindings[idx])
+			}
+		}
+	}
+	return bindings
+}
+
+// SetSourcePass makes the analyzer skip the sink arguments that pass proves
+// clean. The configured sources must be a subset of DefaultSources.
+func (a *Analyzer) SetSourcePass(pass *SourcePass) {
+	a.sourcePass = pass
+}
+
+// mayBeTainted consults the shared source pass, if any, before a value is
+// traced for the sink being checked.
+func (a *Analyzer) mayBeTainted(v ssa.Value) bool {
+	return a.sourcePass == nil || a.sourcePass.MayBeTainted(v, a.labels)
+}
+
+// usesDefaultSources reports whether every source of config belongs to
+// DefaultSources, which is required for the shared source pass to be sound.
+func usesDefaultSources(config *Config) bool {
+	known := make(map[string]Source, len(DefaultSources))
+	for _, src := range DefaultSources {
+		known[formatSourceKey(src)] = src
+	}
+	for _, src := range config.Sources {
+		if catalog, ok := known[formatSourceKey(src)]; !ok || catalog != src {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package taint
+
+import "golang.org/x/tools/go/ssa"
+
+// funcSummary caches per-function taint facts for the duration of one
+// Analyze run.
+type funcSummary struct {
+	// taintedParams records parameters proven to receive tainted data under
+	// the sink labels in effect. Only positive results are recorded, since a
+	// negative result may depend on the recursion depth at which it was computed.
+	taintedParams map[taintedParam]bool
+	// paramsToReturn[i] reports whether parameter i flows into a return
+	// value. It is nil until first requested.
+	paramsToReturn []bool
+}
+
+// taintedParam identifies a parameter proven tainted for a set of sink labels.
+type taintedParam struct {
+	idx    int
+	labels Label
+}
+
+// summary returns the cached summary for fn, creating it on first use. It
+// returns nil outside of an Analyze run.
+func (a *Analyzer) summary(fn *ssa.Function) *funcSummary {
+	if a.summaries == nil {
+		return nil
+	}
+	s, ok := a.summaries[fn]
+	if !ok {
+		s = &funcSummary{taintedParams: make(map[taintedParam]bool)}
+		a.summaries[fn] = s
+	}
+	return s
+}
+
+// isParamKnownTainted reports whether parameter idx of fn was already proven tainted.
+func (a *Analyzer) isParamKnownTainted(fn *ssa.Function, idx int) bool {
+	s := a.summary(fn)
+	return s != nil && s.taintedParams[taintedParam{idx, a.labels}]
+}
+
+// markParamTainted records that parameter idx of fn receives tainted data.
+func (a *Analyzer) markParamTainted(fn *ssa.Function, idx int) {
+	if s := a.summary(fn); s != nil {
+		s.taintedParams[taintedParam{idx, a.labels}] = true
+	}
+}
+
+// paramFlowsToReturn reports whether parameter idx of fn is data-derived into
+// any of its return values. The result is computed once per function.
+func (a *Analyzer) paramFlowsToReturn(fn *ssa.Function, idx int) bool {
+	if idx < 0 || idx >= len(fn.Params) {
+		return false
+	}
+	s := a.summary(fn)
+	if s != nil && s.paramsToReturn != nil {
+		return s.paramsToReturn[idx]
+	}
+
+	flows := make([]bool, len(fn.Params))
+	for i, param := range fn.Params {
+		flows[i] = a.returnReachableFromParam(fn, param)
+	}
+	if s != nil {
+		s.paramsToReturn = flows
+	}
+	return flows[idx]
+}
+
+func (a *Analyzer) returnReachableFromParam(fn *ssa.Function, param *ssa.Parameter) bool {
+	params := map[*ssa.Parameter]bool{param: true}
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			ret, ok := instr.(*ssa.Return)
+			if !ok {
+				continue
+			}
+			for _, retVal := range ret.Results {
+				if a.valueReachableFromParams(retVal, params, make(map[ssa.Value]bool), 0) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -11,8 +11,11 @@
 package taint
 
 import (
+	"go/constant"
 	"go/token"
 	"go/types"
+	"regexp"
+	"slices"
 	"strings"
 
 	"golang.org/x/tools/go/callgraph"
@@ -20,13 +23,15 @@ import (
 	"golang.org/x/tools/go/ssa"
 )
 
-// maxTaintDepth limits recursion depth to prevent stack overflow on large codebases
+// maxTaintDepth limits recursion depth to prevent stack overflow on large codebases.
+// It is the default of Limits.MaxDepth.
 const maxTaintDepth = 50
 
 // maxCallerEdges caps the number of incoming call graph edges examined per function
 // in isParameterTainted. CHA over-approximates call graphs (every interface method
 // call fans out to ALL implementations), so a function can have thousands of callers.
 // Real taint flows come from direct/nearby callers, not the 33rd+ CHA-generated edge.
+// It is the default of Limits.MaxCallerEdges.
 const maxCallerEdges = 32
 
 // isContextType checks if a type is context.Context.
@@ -56,14 +61,29 @@ func isContextType(t types.Type) bool {
 type Source struct {
 	// Package is the import path of the package containing the source (e.g., "net/http")
 	Package string
+	// Receiver is the type name for method sources (e.g., "Rows"), or empty
+	Receiver string
 	// Name is the type or function name that produces tainted data (e.g., "Request" for type, "Get" for function)
 	Name string
-	// Pointer indicates whether the source is a pointer type (true for *Type)
+	// Pointer indicates whether the source is a pointer type (true for *Type),
+	// or for method sources whether the receiver is a pointer
 	Pointer bool
 	// IsFunc marks this source as a function/method that returns tainted data
 	// (e.g., os.Getenv, os.ReadFile). When false, Source is treated as a type
 	// that is only tainted when received as a function parameter from external callers.
 	IsFunc bool
+	// Scan marks a function source that writes tainted data into its pointer
+	// arguments instead of returning it (e.g., (*sql.Rows).Scan).
+	Scan bool
+	// Returned marks a type source whose values are also tainted when returned
+	// by a call (e.g., the *redis.StringCmd reply of a Redis command).
+	Returned bool
+	// FilePath marks a function source whose first argument is the path of the
+	// file read. Files matching Config.TrustedFiles are not considered tainted.
+	FilePath bool
+	// Label classifies the data produced by the source (e.g. LabelHTTPInput).
+	// Unlabelled sources are relevant to every sink.
+	Label Label
 }
 
 // Sink defines a dangerous function that should not receive tainted data.
@@ -91,6 +111,30 @@ type Sink struct {
 	// The sink only fires when every guarded argument's type implements (or equals)
 	// the named interface/type. When empty, no type constraint is applied.
 	ArgTypeGuards map[int]string
+
+	// ArgFields narrows the taint check of a struct argument to specific fields.
+	// Key is the zero-based argument index; value lists the field names whose
+	// assigned values are checked (e.g. http.SetCookie only cares about
+	// Cookie.Name and Cookie.Value). When the argument cannot be traced back to
+	// a local struct allocation, the whole argument is checked instead.
+	ArgFields map[int][]string
+
+	// MapStore marks the sink as an assignment into a map whose named type is
+	// Package.Receiver (e.g. h["X-Key"] = v on an http.Header). Method is
+	// ignored. CheckArgs index 0 selects the key and index 1 the stored value;
+	// if CheckArgs is empty, both are checked.
+	MapStore bool
+
+	// Make marks the sink as the size operands of the make builtin: the
+	// length and capacity of a slice, the size hint of a map and the buffer
+	// size of a channel. Package, Receiver and Method are ignored. CheckArgs
+	// index 0 selects the length (or size) and index 1 the capacity; if
+	// CheckArgs is empty, both are checked.
+	Make bool
+
+	// Labels selects the sources relevant to the sink (e.g. LabelHTTPInput|LabelCLI).
+	// When zero, every configured source is considered.
+	Labels Label
 }
 
 // resolveOriginalType traces back through SSA interface-conversion instructions
@@ -192,6 +236,20 @@ type Sanitizer struct {
 	Method string
 	// Pointer indicates whether the receiver is a pointer type
 	Pointer bool
+	// Guard marks a predicate sanitizer (e.g. filepath.IsLocal) that returns a
+	// bool instead of a cleaned value. Its first argument is treated as
+	// sanitized at sinks reachable only through the branch where it returned true.
+	Guard bool
+	// Validator marks a sanitizer returning an error (e.g. validate(x) error).
+	// Its arguments are treated as sanitized at sinks reachable only through
+	// the branch where the returned error is nil.
+	Validator bool
+	// Strips marks a replacement function taking the arguments of
+	// strings.Replace (s, old, new, n) or strings.ReplaceAll (s, old, new).
+	// Its result is sanitized only when it ends a chain of such calls that
+	// replace every occurrence of each character of Strips, one character
+	// per call, with a string free of those characters.
+	Strips string
 }
 
 // Result represents a detected taint flow from source to sink.
@@ -204,6 +262,9 @@ type Result struct {
 	SinkPos token.Pos
 	// Path is the sequence of functions from entry point to the sink
 	Path []*ssa.Function
+	// Label is the label of the source reaching the sink, zero when the flow
+	// comes from unlabelled sources
+	Label Label
 }
 
 // Config holds taint analysis configuration.
@@ -214,24 +275,43 @@ type Config struct {
 	Sinks []Sink
 	// Sanitizers is the list of functions that neutralize taint (optional)
 	Sanitizers []Sanitizer
+	// TrustedFiles lists patterns of file paths whose content is trusted when
+	// read by a FilePath source with a constant path (optional)
+	TrustedFiles []*regexp.Regexp
+	// AllowlistMaps treats map-membership checks (if !allowed[v] { return })
+	// as guards of v, for maps never written with a tainted key (optional)
+	AllowlistMaps bool
+	// Bounded reports whether the sink argument v is bounded when it reaches
+	// the sink in block, e.g. by a dominating upper-bound check. Bounded
+	// arguments are not checked for taint. depthExceeded applies the depth
+	// limit of the analysis to its recursion and records the truncation when
+	// it is reached (optional)
+	Bounded func(v ssa.Value, block *ssa.BasicBlock, depthExceeded func(depth int) bool) bool
 }
 
 // Analyzer performs taint analysis on SSA programs.
-// paramKey identifies a specific parameter of a function for memoization.
-type paramKey struct {
-	fn       *ssa.Function
-	paramIdx int
-}
-
 type Analyzer struct {
-	config          *Config
-	sources         map[string]Source   // keyed by full type string
-	funcSrcs        map[string]Source   // function sources keyed by "pkg.Func"
-	sinks           map[string]Sink     // keyed by full function string
-	sanitizers      map[string]struct{} // keyed by full function string
-	callGraph       *callgraph.Graph
-	prog            *ssa.Program      // set at Analyze time for ArgTypeGuards resolution
-	paramTaintCache map[paramKey]bool // caches true results from isParameterTainted
+	config       *Config
+	sources      map[string]Source    // keyed by full type string
+	funcSrcs     map[string]Source    // function sources keyed by "pkg.Func" or "(*pkg.Type).Method"
+	returnedSrcs map[string]Source    // Returned type sources keyed by full type string
+	sinks        map[string]Sink      // keyed by full function string
+	sanitizers   map[string]struct{}  // keyed by full function string
+	guards       map[string]Sanitizer // guard and validator sanitizers keyed by full function string
+	strippers    map[string]Sanitizer // character stripping sanitizers keyed by full function string
+	callGraph    *callgraph.Graph
+	prog         *ssa.Program                                // set at Analyze time for ArgTypeGuards resolution
+	summaries    map[*ssa.Function]*funcSummary              // per-function taint summaries, live during Analyze
+	sinkBlock    *ssa.BasicBlock                             // block of the sink being checked, used by guard sanitizers
+	labels       Label                                       // labels of the sink being checked, zero for all sources
+	factImporter func(*ssa.Function) (FunctionSummary, bool) // summaries of functions from other packages
+	sourcePass   *SourcePass                                 // source reachability shared by the rules of a package
+	srcFuncs     []*ssa.Function                             // functions of the analyzed package
+	fieldStores  map[fieldRef][]ssa.Value                    // function values stored into struct fields, built on demand
+	globalUses   map[*ssa.Global][]ssa.Instruction           // instructions using package variables, built on demand
+	tracer       *tracer                                     // records the explored values, set by Explain
+	limits       Limits                                      // budgets of the analysis, zero for the defaults
+	truncated    map[Cutoff]bool                             // limits reached since the last Analyze
 }
 
 // SetCallGraph injects a precomputed call graph.
@@ -242,11 +322,14 @@ func (a *Analyzer) SetCallGraph(cg *callgraph.Graph) {
 // New creates a new taint analyzer with the given configuration.
 func New(config *Config) *Analyzer {
 	a := &Analyzer{
-		config:     config,
-		sources:    make(map[string]Source),
-		funcSrcs:   make(map[string]Source),
-		sinks:      make(map[string]Sink),
-		sanitizers: make(map[string]struct{}),
+		config:       config,
+		sources:      make(map[string]Source),
+		funcSrcs:     make(map[string]Source),
+		returnedSrcs: make(map[string]Source),
+		sinks:        make(map[string]Sink),
+		sanitizers:   make(map[string]struct{}),
+		guards:       make(map[string]Sanitizer),
+		strippers:    make(map[string]Sanitizer),
 	}
 
 	// Index sources for fast lookup, separating type sources from function sources
@@ -256,6 +339,9 @@ func New(config *Config) *Analyzer {
 		if src.IsFunc {
 			a.funcSrcs[key] = src
 		}
+		if src.Returned {
+			a.returnedSrcs[key] = src
+		}
 	}
 
 	// Index sinks for fast lookup
@@ -267,6 +353,14 @@ func New(config *Config) *Analyzer {
 	// Index sanitizers for fast lookup
 	for _, san := range config.Sanitizers {
 		key := formatSanitizerKey(san)
+		if san.Guard || san.Validator {
+			a.guards[key] = san
+			continue
+		}
+		if san.Strips != "" {
+			a.strippers[key] = san
+			continue
+		}
 		a.sanitizers[key] = struct{}{}
 	}
 
@@ -275,6 +369,9 @@ func New(config *Config) *Analyzer {
 
 // formatSourceKey creates a lookup key for a source.
 func formatSourceKey(src Source) string {
+	if src.Receiver != "" {
+		return formatSanitizerKey(Sanitizer{Package: src.Package, Receiver: src.Receiver, Method: src.Name, Pointer: src.Pointer})
+	}
 	key := src.Package + "." + src.Name
 	if src.Pointer {
 		key = "*" + key
@@ -322,16 +419,23 @@ func (a *Analyzer) Analyze(prog *ssa.Program, srcFuncs []*ssa.Function) []Result
 		a.callGraph = cha.CallGraph(prog)
 	}
 
-	a.paramTaintCache = make(map[paramKey]bool)
+	a.summaries = make(map[*ssa.Function]*funcSummary)
+	a.srcFuncs = srcFuncs
+	a.fieldStores = nil
+	a.globalUses = nil
+	a.truncated = nil
 
 	var results []Result
 
 	// Find all sink calls in the program
 	for _, fn := range srcFuncs {
+		if a.expired() {
+			break
+		}
 		results = append(results, a.analyzeFunctionSinks(fn)...)
 	}
 
-	a.paramTaintCache = nil
+	a.summaries = nil
 
 	return results
 }
@@ -346,67 +450,264 @@ func (a *Analyzer) analyzeFunctionSinks(fn *ssa.Function) []Result {
 
 	for _, block := range fn.Blocks {
 		for _, instr := range block.Instrs {
-			call, ok := instr.(*ssa.Call)
+			if update, ok := instr.(*ssa.MapUpdate); ok {
+				if result, found := a.analyzeMapStoreSink(update, fn); found {
+					results = append(results, result)
+				}
+				continue
+			}
+			if sizes, ok := makeSizes(instr); ok {
+				if result, found := a.analyzeMakeSink(instr, sizes, fn); found {
+					results = append(results, result)
+				}
+				continue
+			}
+
+			// Calls, go and defer statements, including calls of function values
+			call, ok := instr.(ssa.CallInstruction)
 			if !ok {
 				continue
 			}
 
 			// Check if this call is a sink
-			sink, isSink := a.isSinkCall(call)
+			sink, args, isSink := a.sinkCall(call)
 			if !isSink {
+				// A function of another package whose parameter reaches a sink
+				a.sinkBlock = block
+				if result, found := a.importedSinkResult(call, fn); found {
+					results = append(results, result)
+				}
+				a.sinkBlock = nil
 				continue
 			}
 
 			// Apply ArgTypeGuards: skip this sink if argument type constraints
 			// are not satisfied (e.g. writer is not http.ResponseWriter).
-			if !guardsSatisfied(call.Call.Args, sink, a.prog) {
+			if !guardsSatisfied(args, sink, a.prog) {
 				continue
 			}
 
-			// Determine which arguments to check for taint
-			var argsToCheck []ssa.Value
+			// Determine which argument positions to check for taint
+			var argIndices []int
 
 			if len(sink.CheckArgs) > 0 {
 				// Sink specifies which argument positions to check
 				for _, idx := range sink.CheckArgs {
-					if idx < len(call.Call.Args) {
-						argsToCheck = append(argsToCheck, call.Call.Args[idx])
+					if idx < len(args) {
+						argIndices = append(argIndices, idx)
 					}
 				}
 			} else {
 				// No CheckArgs specified: check all arguments
-				argsToCheck = call.Call.Args
+				for idx := range args {
+					argIndices = append(argIndices, idx)
+				}
 			}
 
 			// Check if any of the specified arguments are tainted
-			for _, arg := range argsToCheck {
-				if a.isTainted(arg, fn, make(map[ssa.Value]bool), 0) {
+			a.sinkBlock = block
+			a.labels = sink.Labels
+			for _, idx := range argIndices {
+				arg := args[idx]
+				if len(sink.ArgFields[idx]) == 0 && !a.mayBeTainted(arg) {
+					continue
+				}
+				if a.isBounded(arg, block) {
+					continue
+				}
+				if a.isSinkArgTainted(arg, sink.ArgFields[idx], fn) {
 					results = append(results, Result{
 						Sink:    sink,
 						SinkPos: call.Pos(),
 						Path:    a.buildPath(fn),
+						Label: a.sourceLabel(sink.Labels, func() bool {
+							return a.isSinkArgTainted(arg, sink.ArgFields[idx], fn)
+						}),
 					})
 					break
 				}
 			}
+			a.sinkBlock = nil
+			a.labels = 0
 		}
 	}
 
 	return results
 }
 
-// isSinkCall checks if a call instruction is a sink and returns the sink info.
-func (a *Analyzer) isSinkCall(call *ssa.Call) (Sink, bool) {
+// isSinkArgTainted checks a single sink argument for taint. When fields is
+// non-empty and the argument is a locally allocated struct, only the stores
+// to the named fields are inspected.
+func (a *Analyzer) isSinkArgTainted(arg ssa.Value, fields []string, fn *ssa.Function) bool {
+	if len(fields) == 0 {
+		return a.isTainted(arg, fn, make(map[ssa.Value]bool), 0)
+	}
+
+	alloc := traceToAlloc(arg)
+	if alloc == nil {
+		return a.isTainted(arg, fn, make(map[ssa.Value]bool), 0)
+	}
+
+	ptr, ok := alloc.Type().Underlying().(*types.Pointer)
+	if !ok {
+		return a.isTainted(arg, fn, make(map[ssa.Value]bool), 0)
+	}
+	st, ok := ptr.Elem().Underlying().(*types.Struct)
+	if !ok {
+		return a.isTainted(arg, fn, make(map[ssa.Value]bool), 0)
+	}
+
+	for i := 0; i < st.NumFields(); i++ {
+		if !slices.Contains(fields, st.Field(i).Name()) {
+			continue
+		}
+		if a.isFieldOfAllocTainted(alloc, i, fn, make(map[ssa.Value]bool), 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzeMapStoreSink reports a taint flow when a tainted key or value is
+// stored into a map whose type is configured as a MapStore sink.
+func (a *Analyzer) analyzeMapStoreSink(update *ssa.MapUpdate, fn *ssa.Function) (Result, bool) {
+	sink, isSink := a.isMapStoreSink(update)
+	if !isSink {
+		return Result{}, false
+	}
+
+	a.sinkBlock = update.Block()
+	a.labels = sink.Labels
+	defer func() { a.sinkBlock, a.labels = nil, 0 }()
+
+	operands := []ssa.Value{update.Key, update.Value}
+	indices := sink.CheckArgs
+	if len(indices) == 0 {
+		indices = []int{0, 1}
+	}
+
+	for _, idx := range indices {
+		if idx < 0 || idx >= len(operands) {
+			continue
+		}
+		if !a.mayBeTainted(operands[idx]) {
+			continue
+		}
+		if a.isTainted(operands[idx], fn, make(map[ssa.Value]bool), 0) {
+			return Result{
+				Sink:    sink,
+				SinkPos: update.Pos(),
+				Path:    a.buildPath(fn),
+				Label: a.sourceLabel(sink.Labels, func() bool {
+					return a.isTainted(operands[idx], fn, make(map[ssa.Value]bool), 0)
+				}),
+			}, true
+		}
+	}
+	return Result{}, false
+}
+
+// analyzeMakeSink reports a taint flow when a tainted size reaches a make
+// configured as a Make sink. sizes are the length (or size) and capacity
+// operands of the instruction, nil when absent.
+func (a *Analyzer) analyzeMakeSink(instr ssa.Instruction, sizes []ssa.Value, fn *ssa.Function) (Result, bool) {
+	sink, isSink := a.makeSink()
+	if !isSink {
+		return Result{}, false
+	}
+
+	a.sinkBlock = instr.Block()
+	a.labels = sink.Labels
+	defer func() { a.sinkBlock, a.labels = nil, 0 }()
+
+	indices := sink.CheckArgs
+	if len(indices) == 0 {
+		indices = []int{0, 1}
+	}
+
+	for _, idx := range indices {
+		if idx < 0 || idx >= len(sizes) || sizes[idx] == nil {
+			continue
+		}
+		size := sizes[idx]
+		if _, isConst := size.(*ssa.Const); isConst || !a.mayBeTainted(size) {
+			continue
+		}
+		if a.isBounded(size, instr.Block()) {
+			continue
+		}
+		if a.isTainted(size, fn, make(map[ssa.Value]bool), 0) {
+			return Result{
+				Sink:    sink,
+				SinkPos: instr.Pos(),
+				Path:    a.buildPath(fn),
+				Label: a.sourceLabel(sink.Labels, func() bool {
+					return a.isTainted(size, fn, make(map[ssa.Value]bool), 0)
+				}),
+			}, true
+		}
+	}
+	return Result{}, false
+}
+
+// makeSizes returns the size operands of a make instruction.
+func makeSizes(instr ssa.Instruction) ([]ssa.Value, bool) {
+	switch v := instr.(type) {
+	case *ssa.MakeSlice:
+		return []ssa.Value{v.Len, v.Cap}, true
+	case *ssa.MakeMap:
+		return []ssa.Value{v.Reserve}, true
+	case *ssa.MakeChan:
+		return []ssa.Value{v.Size}, true
+	}
+	return nil, false
+}
+
+// makeSink returns the Make sink of the configuration, if any.
+func (a *Analyzer) makeSink() (Sink, bool) {
+	for _, sink := range a.sinks {
+		if sink.Make {
+			return sink, true
+		}
+	}
+	return Sink{}, false
+}
+
+// isBounded reports whether the configuration considers the sink argument v
+// bounded in block.
+func (a *Analyzer) isBounded(v ssa.Value, block *ssa.BasicBlock) bool {
+	return a.config.Bounded != nil && a.config.Bounded(v, block, a.beyondDepth)
+}
+
+// isMapStoreSink checks if a map update targets a map type configured as a
+// MapStore sink.
+func (a *Analyzer) isMapStoreSink(update *ssa.MapUpdate) (Sink, bool) {
+	named, ok := update.Map.Type().(*types.Named)
+	if !ok || named.Obj() == nil || named.Obj().Pkg() == nil {
+		return Sink{}, false
+	}
+	pkg := named.Obj().Pkg().Path()
+	name := named.Obj().Name()
+
+	for _, sink := range a.sinks {
+		if sink.MapStore && sink.Package == pkg && sink.Receiver == name {
+			return sink, true
+		}
+	}
+	return Sink{}, false
+}
+
+// isSinkCall checks if a call is a sink and returns the sink info.
+func (a *Analyzer) isSinkCall(call *ssa.CallCommon) (Sink, bool) {
 	// Try to get receiver info first (works for both concrete and interface calls)
 	var pkg, receiverName, methodName string
-	var isPointer bool
 
 	// Check for method call (invoke or static with receiver)
-	if call.Call.IsInvoke() {
+	if call.IsInvoke() {
 		// Interface method call - receiver is in Call.Value, not Args
-		if call.Call.Value != nil {
-			recvType := call.Call.Value.Type()
-			methodName = call.Call.Method.Name()
+		if call.Value != nil {
+			recvType := call.Value.Type()
+			methodName = call.Method.Name()
 
 			// For interface calls, the type is usually a Named type pointing to the interface
 			if named, ok := recvType.(*types.Named); ok {
@@ -418,6 +719,9 @@ func (a *Analyzer) isSinkCall(call *ssa.Call) (Sink, bool) {
 
 			// Match against sinks (interface methods don't have Pointer field usually)
 			for _, sink := range a.sinks {
+				if sink.Make {
+					continue
+				}
 				if sink.Package == pkg && sink.Receiver == receiverName && sink.Method == methodName {
 					return sink, true
 				}
@@ -426,7 +730,14 @@ func (a *Analyzer) isSinkCall(call *ssa.Call) (Sink, bool) {
 	}
 
 	// Try static callee (for non-interface method calls and functions)
-	callee := call.Call.StaticCallee()
+	return a.isSinkFunc(call.StaticCallee())
+}
+
+// isSinkFunc checks if a function or method is a sink and returns the sink info.
+func (a *Analyzer) isSinkFunc(callee *ssa.Function) (Sink, bool) {
+	var pkg, receiverName, methodName string
+	var isPointer bool
+
 	if callee != nil {
 		if callee.Pkg != nil && callee.Pkg.Pkg != nil {
 			pkg = callee.Pkg.Pkg.Path()
@@ -451,7 +762,7 @@ func (a *Analyzer) isSinkCall(call *ssa.Call) (Sink, bool) {
 	// Match against configured sinks
 	for _, sink := range a.sinks {
 		// Package must match
-		if sink.Package != pkg {
+		if sink.Make || sink.Package != pkg {
 			continue
 		}
 
@@ -473,14 +784,99 @@ func (a *Analyzer) isSinkCall(call *ssa.Call) (Sink, bool) {
 
 // isSanitizerCall checks if a call instruction is a sanitizer.
 func (a *Analyzer) isSanitizerCall(call *ssa.Call) bool {
-	if len(a.sanitizers) == 0 {
+	if len(a.sanitizers) == 0 && len(a.strippers) == 0 {
 		return false
 	}
 
-	callee := call.Call.StaticCallee()
-	if callee == nil {
+	key, ok := sanitizerKeyForCall(&call.Call)
+	if !ok {
 		return false
 	}
+	if _, found := a.sanitizers[key]; found {
+		return true
+	}
+	if san, found := a.strippers[key]; found {
+		return a.stripsAll(call, san.Strips)
+	}
+	return false
+}
+
+// stripsAll reports whether call ends a chain of calls of stripping
+// sanitizers that together remove every character of chars, none of them
+// inserting one of those characters back.
+func (a *Analyzer) stripsAll(call *ssa.Call, chars string) bool {
+	remaining := chars
+	var v ssa.Value = call
+	for depth := 0; remaining != ""; depth++ {
+		inner, ok := v.(*ssa.Call)
+		if !ok || len(inner.Call.Args) < 3 || a.beyondDepth(depth) {
+			return false
+		}
+		key, ok := sanitizerKeyForCall(&inner.Call)
+		if !ok {
+			return false
+		}
+		if _, found := a.strippers[key]; !found {
+			return false
+		}
+		replacement, ok := constString(inner.Call.Args[2])
+		if !ok || strings.ContainsAny(replacement, chars) {
+			return false
+		}
+		if char, ok := strippedChar(&inner.Call); ok {
+			remaining = strings.ReplaceAll(remaining, char, "")
+		}
+		v = inner.Call.Args[0]
+	}
+	return true
+}
+
+// strippedChar returns the single character whose every occurrence is
+// replaced by a replacement call.
+func strippedChar(call *ssa.CallCommon) (string, bool) {
+	old, ok := constString(call.Args[1])
+	if !ok || len([]rune(old)) != 1 {
+		return "", false
+	}
+	if len(call.Args) > 3 {
+		// Only a negative count replaces every occurrence
+		n, ok := call.Args[3].(*ssa.Const)
+		if !ok || n.Value == nil || n.Value.Kind() != constant.Int || constant.Sign(n.Value) >= 0 {
+			return "", false
+		}
+	}
+	return old, true
+}
+
+// constString returns the value of a string constant.
+func constString(v ssa.Value) (string, bool) {
+	c, ok := v.(*ssa.Const)
+	if !ok || c.Value == nil || c.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(c.Value), true
+}
+
+// guardSanitizer returns the guard or validator sanitizer invoked by call.
+func (a *Analyzer) guardSanitizer(call *ssa.Call) (Sanitizer, bool) {
+	if len(a.guards) == 0 {
+		return Sanitizer{}, false
+	}
+
+	key, ok := sanitizerKeyForCall(&call.Call)
+	if !ok {
+		return Sanitizer{}, false
+	}
+	san, found := a.guards[key]
+	return san, found
+}
+
+// sanitizerKeyForCall builds the sanitizer lookup key for a static call.
+func sanitizerKeyForCall(call *ssa.CallCommon) (string, bool) {
+	callee := call.StaticCallee()
+	if callee == nil {
+		return "", false
+	}
 
 	var pkg, receiverName, methodName string
 	var isPointer bool
@@ -503,15 +899,12 @@ func (a *Analyzer) isSanitizerCall(call *ssa.Call) bool {
 		}
 	}
 
-	// Build key and check
-	key := formatSanitizerKey(Sanitizer{
+	return formatSanitizerKey(Sanitizer{
 		Package:  pkg,
 		Receiver: receiverName,
 		Method:   methodName,
 		Pointer:  isPointer,
-	})
-	_, found := a.sanitizers[key]
-	return found
+	}), true
 }
 
 // isTainted recursively checks if a value is tainted (originates from a source).
@@ -522,17 +915,26 @@ func (a *Analyzer) isSanitizerCall(call *ssa.Call) bool {
 // URL) are NOT automatically considered tainted — their taintedness depends
 // on whether the data flowing into them is tainted.
 func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
+	if a.tracer != nil && v != nil {
+		return a.traced(v, fn, func() bool { return a.isValueTainted(v, fn, visited, depth) })
+	}
+	return a.isValueTainted(v, fn, visited, depth)
+}
+
+func (a *Analyzer) isValueTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
 	if v == nil {
 		return false
 	}
 
 	// Prevent stack overflow on large codebases
-	if depth > maxTaintDepth {
+	if a.beyondDepth(depth) {
+		a.cut(v, CutoffDepth)
 		return false
 	}
 
 	// Prevent infinite recursion
 	if visited[v] {
+		a.cut(v, CutoffVisited)
 		return false
 	}
 	visited[v] = true
@@ -543,6 +945,13 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 		return false
 	}
 
+	// Values validated by a guard sanitizer (e.g. filepath.IsLocal) are clean
+	// at sinks that can only be reached through the passing branch.
+	if a.isGuardedAtSink(v) {
+		a.cut(v, CutoffGuard)
+		return false
+	}
+
 	// Trace back through SSA instructions
 	switch val := v.(type) {
 	case *ssa.Parameter:
@@ -554,6 +963,7 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 	case *ssa.Call:
 		// FIRST: Check if this call is a sanitizer — sanitizers break the taint chain
 		if a.isSanitizerCall(val) {
+			a.cut(val, CutoffSanitizer)
 			return false
 		}
 
@@ -562,6 +972,23 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 			return true
 		}
 
+		// Modelled builtins and stdlib transformers only propagate the
+		// arguments listed in their propagation model.
+		if prop, ok := propagationFor(&val.Call); ok {
+			return a.isResultTaintedByModel(val, prop, fn, visited, depth+1)
+		}
+
+		// Functions of other packages summarized through analysis facts only
+		// propagate the parameters that reach their return values.
+		if summary, ok := a.importedSummary(val.Call.StaticCallee()); ok {
+			for _, idx := range summary.ReturnParams {
+				if idx < len(val.Call.Args) && a.isTainted(val.Call.Args[idx], fn, visited, depth+1) {
+					return true
+				}
+			}
+			return false
+		}
+
 		// For method calls, check if the receiver carries taint.
 		// This handles patterns like: req.URL.Query().Get("param")
 		// where req is a tainted *http.Request parameter.
@@ -574,6 +1001,7 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 			// Skip context.Context args — they don't carry user data to outputs.
 			for _, arg := range val.Call.Args {
 				if isContextType(arg.Type()) {
+					a.cut(arg, CutoffContextType)
 					continue
 				}
 				if a.isTainted(arg, fn, visited, depth+1) {
@@ -596,6 +1024,7 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 				// Skip context.Context args — they don't carry user data to outputs.
 				for _, arg := range val.Call.Args[1:] {
 					if isContextType(arg.Type()) {
+						a.cut(arg, CutoffContextType)
 						continue
 					}
 					if a.isTainted(arg, fn, visited, depth+1) {
@@ -622,6 +1051,7 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 					// Skip context.Context args — they don't carry user data to outputs.
 					for _, arg := range val.Call.Args {
 						if isContextType(arg.Type()) {
+							a.cut(arg, CutoffContextType)
 							continue
 						}
 						if a.isTainted(arg, fn, visited, depth+1) {
@@ -652,6 +1082,10 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 		return a.isTainted(val.X, fn, visited, depth+1)
 
 	case *ssa.UnOp:
+		// Channel receive - check the values sent on the channel
+		if val.Op == token.ARROW {
+			return a.isReceivedTainted(val, fn, visited, depth+1)
+		}
 		// Unary operation (like pointer dereference)
 		return a.isTainted(val.X, fn, visited, depth+1)
 
@@ -713,11 +1147,34 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 				}
 			}
 		}
+		// Writes through modelled calls (e.g. b.WriteString(s), copy(dst, src))
+		if a.isWrittenWithTaint(val, fn, visited, depth+1) {
+			return true
+		}
+		// Destinations of scanning sources (e.g. rows.Scan(&name))
+		if a.isScanDestination(val) {
+			return true
+		}
 
 	case *ssa.Lookup:
-		// Map/string lookup - check the map/string
+		// Map lookup - check the values stored into the map
+		if _, isMap := val.X.Type().Underlying().(*types.Map); isMap {
+			return a.isMapEntryTainted(val.X, false, fn, visited, depth+1)
+		}
+		// String lookup - check the string
 		return a.isTainted(val.X, fn, visited, depth+1)
 
+	case *ssa.Next:
+		// Range over a map or string - check its keys and values
+		rng, ok := val.Iter.(*ssa.Range)
+		if !ok {
+			return false
+		}
+		if _, isMap := rng.X.Type().Underlying().(*types.Map); isMap {
+			return a.isMapEntryTainted(rng.X, true, fn, visited, depth+1)
+		}
+		return a.isTainted(rng.X, fn, visited, depth+1)
+
 	case *ssa.MakeSlice:
 		// MakeSlice - check if it's being populated with tainted data
 		if refs := val.Referrers(); refs != nil {
@@ -753,7 +1210,7 @@ func (a *Analyzer) isTainted(v ssa.Value, fn *ssa.Function, visited map[ssa.Valu
 		// Global variables - check if configured as a known source (e.g., os.Args)
 		if val.Pkg != nil && val.Pkg.Pkg != nil {
 			globalKey := val.Pkg.Pkg.Path() + "." + val.Name()
-			if _, ok := a.sources[globalKey]; ok {
+			if a.isActiveSource(globalKey) {
 				return true
 			}
 		}
@@ -784,7 +1241,7 @@ func (a *Analyzer) isSourceType(t types.Type) bool {
 	typeStr := t.String()
 
 	// Direct match
-	if _, ok := a.sources[typeStr]; ok {
+	if a.isActiveSource(typeStr) {
 		return true
 	}
 
@@ -793,11 +1250,11 @@ func (a *Analyzer) isSourceType(t types.Type) bool {
 		obj := named.Obj()
 		if obj != nil && obj.Pkg() != nil {
 			key := obj.Pkg().Path() + "." + obj.Name()
-			if _, ok := a.sources[key]; ok {
+			if a.isActiveSource(key) {
 				return true
 			}
 			// Check pointer variant
-			if _, ok := a.sources["*"+key]; ok {
+			if a.isActiveSource("*" + key) {
 				return true
 			}
 		}
@@ -839,24 +1296,118 @@ func mayHaveExternalCallers(fn *ssa.Function) bool {
 }
 
 // isSourceFuncCall checks if a call invokes a known source function
-// (a function explicitly configured as producing tainted data, e.g., os.Getenv).
+// (a function explicitly configured as producing tainted data, e.g., os.Getenv),
+// or returns a value of a Returned source type.
 func (a *Analyzer) isSourceFuncCall(call *ssa.Call) bool {
-	callee := call.Call.StaticCallee()
-	if callee == nil {
-		return false
+	if src, ok := a.sourceFunc(&call.Call); ok && !src.Scan && a.labels.matches(src.Label) {
+		return !a.isTrustedFileRead(src, &call.Call)
+	}
+	if src, ok := a.returnedSource(call.Type()); ok && a.labels.matches(src.Label) {
+		return true
 	}
+	return false
+}
 
-	if callee.Pkg != nil && callee.Pkg.Pkg != nil {
-		pkg := callee.Pkg.Pkg.Path()
-		funcKey := pkg + "." + callee.Name()
-		if src, ok := a.sources[funcKey]; ok && src.IsFunc {
+// sourceFunc returns the function source invoked by call, if any.
+func (a *Analyzer) sourceFunc(call *ssa.CallCommon) (Source, bool) {
+	if len(a.funcSrcs) == 0 {
+		return Source{}, false
+	}
+	key, ok := sanitizerKeyForCall(call)
+	if !ok {
+		return Source{}, false
+	}
+	src, found := a.funcSrcs[key]
+	return src, found
+}
+
+// isScanDestination reports whether ptr is passed to a Scan source relevant to
+// the sink being checked, either directly or through the variadic ...any
+// argument (e.g. rows.Scan(&id, &name)).
+func (a *Analyzer) isScanDestination(ptr ssa.Value) bool {
+	if len(a.funcSrcs) == 0 {
+		return false
+	}
+	for _, call := range scanCalls(ptr) {
+		if src, ok := a.sourceFunc(call); ok && src.Scan && a.labels.matches(src.Label) {
 			return true
 		}
 	}
+	return false
+}
 
+// scanCalls returns the calls receiving ptr as an argument, directly, boxed in
+// an interface or stored into the backing array of a variadic slice.
+func scanCalls(ptr ssa.Value) []*ssa.CallCommon {
+	var calls []*ssa.CallCommon
+	refs := ptr.Referrers()
+	if refs == nil {
+		return nil
+	}
+	for _, ref := range *refs {
+		switch instr := ref.(type) {
+		case *ssa.MakeInterface:
+			calls = append(calls, scanCalls(instr)...)
+		case *ssa.ChangeType:
+			calls = append(calls, scanCalls(instr)...)
+		case *ssa.Store:
+			elem, ok := instr.Addr.(*ssa.IndexAddr)
+			if !ok || instr.Val != ptr {
+				continue
+			}
+			array, ok := elem.X.(*ssa.Alloc)
+			if !ok || array.Referrers() == nil {
+				continue
+			}
+			for _, arrayRef := range *array.Referrers() {
+				if slice, ok := arrayRef.(*ssa.Slice); ok {
+					calls = append(calls, scanCalls(slice)...)
+				}
+			}
+		case ssa.CallInstruction:
+			if slices.Contains(instr.Common().Args, ptr) {
+				calls = append(calls, instr.Common())
+			}
+		}
+	}
+	return calls
+}
+
+// returnedSource returns the Returned source matching type t, if any.
+func (a *Analyzer) returnedSource(t types.Type) (Source, bool) {
+	if len(a.returnedSrcs) == 0 {
+		return Source{}, false
+	}
+	src, ok := a.returnedSrcs[t.String()]
+	return src, ok
+}
+
+// isTrustedFileRead reports whether a FilePath source reads a constant path
+// matching one of the trusted file patterns.
+func (a *Analyzer) isTrustedFileRead(src Source, call *ssa.CallCommon) bool {
+	if !src.FilePath || len(a.config.TrustedFiles) == 0 || len(call.Args) == 0 {
+		return false
+	}
+	c, ok := call.Args[0].(*ssa.Const)
+	if !ok || c.Value == nil || c.Value.Kind() != constant.String {
+		return false
+	}
+	path := constant.StringVal(c.Value)
+	for _, pattern := range a.config.TrustedFiles {
+		if pattern.MatchString(path) {
+			return true
+		}
+	}
 	return false
 }
 
+// isActiveSource reports whether key names a configured source whose label is
+// relevant to the sink being checked.
+func (a *Analyzer) isActiveSource(key string) bool {
+	src, ok := a.sources[key]
+	return ok && a.labels.matches(src.Label)
+}
+
 // isParameterTainted checks if a function parameter receives tainted data.
 //
 // A parameter is tainted if:
@@ -864,7 +1415,7 @@ func (a *Analyzer) isSourceFuncCall(call *ssa.Call) bool {
 // 2. Any caller passes tainted data to the corresponding argument position
 func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
 	// Prevent stack overflow
-	if depth > maxTaintDepth {
+	if a.beyondDepth(depth) {
 		return false
 	}
 
@@ -877,12 +1428,9 @@ func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, vi
 		}
 	}
 
-	// Check memoization cache (only true results are cached).
-	if paramIdx >= 0 && a.paramTaintCache != nil {
-		key := paramKey{fn: fn, paramIdx: paramIdx}
-		if a.paramTaintCache[key] {
-			return true
-		}
+	// Check the function summary (only true results are recorded).
+	if paramIdx >= 0 && a.isParamKnownTainted(fn, paramIdx) {
+		return true
 	}
 
 	// Use call graph to find callers and check their arguments
@@ -890,8 +1438,8 @@ func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, vi
 		// No call graph: fall back to type-based auto-taint for source-typed params
 		// (conservative — may produce false positives, but we have no callee info).
 		if a.isSourceType(param.Type()) {
-			if paramIdx >= 0 && a.paramTaintCache != nil {
-				a.paramTaintCache[paramKey{fn: fn, paramIdx: paramIdx}] = true
+			if paramIdx >= 0 {
+				a.markParamTainted(fn, paramIdx)
 			}
 			return true
 		}
@@ -916,8 +1464,8 @@ func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, vi
 	if a.isSourceType(param.Type()) {
 		isEntryPoint := (node == nil || len(node.In) == 0)
 		if isEntryPoint || mayHaveExternalCallers(fn) {
-			if paramIdx >= 0 && a.paramTaintCache != nil {
-				a.paramTaintCache[paramKey{fn: fn, paramIdx: paramIdx}] = true
+			if paramIdx >= 0 {
+				a.markParamTainted(fn, paramIdx)
 			}
 			return true
 		}
@@ -950,7 +1498,9 @@ func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, vi
 	// explosion from CHA over-approximation of interface method calls.
 	edgesChecked := 0
 	for _, inEdge := range node.In {
-		if edgesChecked >= maxCallerEdges {
+		if edgesChecked >= a.callerEdgeLimit() {
+			a.truncate(CutoffCallerEdges)
+			a.cut(param, CutoffCallerEdges)
 			break
 		}
 
@@ -964,9 +1514,7 @@ func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, vi
 		if adjustedIdx < len(callArgs) {
 			edgesChecked++
 			if a.isTainted(callArgs[adjustedIdx], inEdge.Caller.Func, visited, depth+1) {
-				if a.paramTaintCache != nil {
-					a.paramTaintCache[paramKey{fn: fn, paramIdx: paramIdx}] = true
-				}
+				a.markParamTainted(fn, paramIdx)
 				return true
 			}
 		}
@@ -978,7 +1526,7 @@ func (a *Analyzer) isParameterTainted(param *ssa.Parameter, fn *ssa.Function, vi
 // isFreeVarTainted checks if a closure's free variable is tainted.
 // Free variables are captured from the enclosing function's scope.
 func (a *Analyzer) isFreeVarTainted(fv *ssa.FreeVar, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
-	if depth > maxTaintDepth {
+	if a.beyondDepth(depth) {
 		return false
 	}
 
@@ -1017,7 +1565,7 @@ func (a *Analyzer) isFreeVarTainted(fv *ssa.FreeVar, fn *ssa.Function, visited m
 // the entire struct as tainted when any field is tainted, we trace the
 // specific field to see if IT was assigned tainted data.
 func (a *Analyzer) isFieldAccessTainted(fa *ssa.FieldAddr, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
-	if depth > maxTaintDepth {
+	if a.beyondDepth(depth) {
 		return false
 	}
 
@@ -1087,7 +1635,7 @@ func (a *Analyzer) isFieldAccessTainted(fa *ssa.FieldAddr, fn *ssa.Function, vis
 
 // isFieldTaintedOnValue checks if a specific field of a value is tainted.
 func (a *Analyzer) isFieldTaintedOnValue(v ssa.Value, fieldIdx int, fn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
-	if v == nil || depth > maxTaintDepth {
+	if v == nil || a.beyondDepth(depth) {
 		return false
 	}
 
@@ -1128,12 +1676,18 @@ func (a *Analyzer) isFieldOfAllocTainted(alloc *ssa.Alloc, fieldIdx int, fn *ssa
 	if alloc.Referrers() == nil {
 		return false
 	}
+	if a.isScanDestination(alloc) {
+		return true
+	}
 	for _, ref := range *alloc.Referrers() {
 		fa, ok := ref.(*ssa.FieldAddr)
 		if !ok || fa.Field != fieldIdx {
 			continue
 		}
 
+		if a.isScanDestination(fa) {
+			return true
+		}
 		if fa.Referrers() == nil {
 			continue
 		}
@@ -1162,7 +1716,7 @@ func (a *Analyzer) isFieldAccessOnPointerTainted(unop *ssa.UnOp, fieldIdx int, f
 // It looks inside the callee to find the returned struct allocation and checks
 // whether the specific field was assigned data derived from tainted arguments.
 func (a *Analyzer) isFieldTaintedViaCall(call *ssa.Call, fieldIdx int, callee *ssa.Function, callerFn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
-	if depth > maxTaintDepth || callee == nil {
+	if a.beyondDepth(depth) || callee == nil {
 		return false
 	}
 
@@ -1210,7 +1764,7 @@ func (a *Analyzer) isFieldTaintedViaCall(call *ssa.Call, fieldIdx int, callee *s
 // isFieldOfAllocTaintedInCallee checks if a specific field of an allocated struct
 // (inside a callee function) receives tainted data from the caller's arguments.
 func (a *Analyzer) isFieldOfAllocTaintedInCallee(alloc *ssa.Alloc, fieldIdx int, callee *ssa.Function, call *ssa.Call, callerFn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
-	if alloc.Referrers() == nil || depth > maxTaintDepth {
+	if alloc.Referrers() == nil || a.beyondDepth(depth) {
 		return false
 	}
 
@@ -1244,7 +1798,7 @@ func (a *Analyzer) isFieldOfAllocTaintedInCallee(alloc *ssa.Alloc, fieldIdx int,
 // isCalleValueTainted checks if a value inside a callee is tainted, mapping
 // callee parameters back to the actual caller arguments for interprocedural analysis.
 func (a *Analyzer) isCalleValueTainted(v ssa.Value, callee *ssa.Function, call *ssa.Call, callerFn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
-	if v == nil || depth > maxTaintDepth {
+	if v == nil || a.beyondDepth(depth) {
 		return false
 	}
 
@@ -1336,45 +1890,23 @@ func (a *Analyzer) isCalleValueTainted(v ssa.Value, callee *ssa.Function, call *
 // where only some arguments flow into the return struct, while others are stored
 // in fields that don't affect the data being tracked.
 func (a *Analyzer) doTaintedArgsFlowToReturn(call *ssa.Call, callee *ssa.Function, callerFn *ssa.Function, visited map[ssa.Value]bool, depth int) bool {
-	if depth > maxTaintDepth {
+	if a.beyondDepth(depth) {
 		return false
 	}
 
-	// Identify which args are tainted.
+	// Only arguments whose parameter reaches a return (per the callee's
+	// summary) need to be checked for taint.
 	// Skip context.Context args — they don't carry user data to outputs.
-	var taintedArgIndices []int
 	for i, arg := range call.Call.Args {
 		if isContextType(arg.Type()) {
+			a.cut(arg, CutoffContextType)
 			continue
 		}
-		if a.isTainted(arg, callerFn, visited, depth) {
-			taintedArgIndices = append(taintedArgIndices, i)
-		}
-	}
-	if len(taintedArgIndices) == 0 {
-		return false
-	}
-
-	// Build a set of callee parameters that correspond to tainted caller args
-	taintedParams := make(map[*ssa.Parameter]bool)
-	for _, idx := range taintedArgIndices {
-		if idx < len(callee.Params) {
-			taintedParams[callee.Params[idx]] = true
+		if !a.paramFlowsToReturn(callee, i) {
+			continue
 		}
-	}
-
-	// Check if any tainted parameter flows to a Return instruction
-	for _, block := range callee.Blocks {
-		for _, instr := range block.Instrs {
-			ret, ok := instr.(*ssa.Return)
-			if !ok {
-				continue
-			}
-			for _, retVal := range ret.Results {
-				if a.valueReachableFromParams(retVal, taintedParams, make(map[ssa.Value]bool), 0) {
-					return true
-				}
-			}
+		if a.isTainted(arg, callerFn, visited, depth) {
+			return true
 		}
 	}
 
@@ -1423,6 +1955,10 @@ func (a *Analyzer) valueReachableFromParams(v ssa.Value, taintedParams map[*ssa.
 		}
 		return false
 	case *ssa.Call:
+		// Sanitizers break the flow
+		if a.isSanitizerCall(val) {
+			return false
+		}
 		// Check if any arg to this call comes from tainted params
 		for _, arg := range val.Call.Args {
 			if a.valueReachableFromParams(arg, taintedParams, visited, depth+1) {
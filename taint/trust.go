@@ -0,0 +1,251 @@
+package taint
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/securego/gosec/v2/issue"
+)
+
+// TrustProfile adjusts the sources considered by a rule, and the functions it
+// trusts to validate values. Source entries are either labels ("http-input",
+// "env", "file-content", "network", "cli", "stored") or source names from
+// DefaultSources ("os.Getenv", "net/http.Request", "(*database/sql.Rows).Scan").
+// Validator and guard entries name functions the same way
+// ("example.com/app/check.ID", "(*example.com/app.Policy).Allowed").
+//
+// It is read from the rule's section of the gosec configuration:
+//
+//	{
+//	  "G702": {"trusted": ["os.Args", "env"]},
+//	  "G701": {"untrusted": ["file-content"], "validators": ["example.com/app/check.ID"]},
+//	  "G705": {"untrusted": ["stored"], "trusted_files": ["^/etc/myapp/"]}
+//	}
+type TrustProfile struct {
+	// Trusted sources are removed from the rule.
+	Trusted []string
+	// Untrusted sources are added to the rule, and their labels to its sinks.
+	Untrusted []string
+	// TrustedFiles are patterns of file paths whose content is trusted.
+	TrustedFiles []string
+	// Validators are functions returning an error; their arguments are clean
+	// where the error is nil.
+	Validators []string
+	// Guards are functions returning a bool; their first argument is clean
+	// where they returned true.
+	Guards []string
+}
+
+// ParseTrustProfile reads the "trusted", "untrusted", "trusted_files",
+// "validators" and "guards" lists from the configuration section of a rule.
+// Unknown shapes are ignored.
+func ParseTrustProfile(section any) TrustProfile {
+	var profile TrustProfile
+	m, ok := section.(map[string]any)
+	if !ok {
+		return profile
+	}
+	profile.Trusted = toStrings(m["trusted"])
+	profile.Untrusted = toStrings(m["untrusted"])
+	profile.TrustedFiles = toStrings(m["trusted_files"])
+	profile.Validators = toStrings(m["validators"])
+	profile.Guards = toStrings(m["guards"])
+	return profile
+}
+
+func toStrings(v any) []string {
+	switch list := v.(type) {
+	case []string:
+		return list
+	case []any:
+		var out []string
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// ParseLabel returns the label named name (e.g. "env").
+func ParseLabel(name string) (Label, bool) {
+	for i, labelName := range labelNames {
+		if labelName == name {
+			return 1 << i, true
+		}
+	}
+	return 0, false
+}
+
+// WithTrust returns a copy of c honouring profile. Untrusted entries are
+// applied first, so an entry listed on both sides ends up trusted.
+func (c Config) WithTrust(profile TrustProfile) (Config, error) {
+	if len(profile.Trusted) == 0 && len(profile.Untrusted) == 0 && len(profile.TrustedFiles) == 0 &&
+		len(profile.Validators) == 0 && len(profile.Guards) == 0 {
+		return c, nil
+	}
+
+	sanitizers := slices.Clone(c.Sanitizers)
+	for _, name := range profile.Validators {
+		san, err := parseSanitizerName(name)
+		if err != nil {
+			return c, err
+		}
+		san.Validator = true
+		sanitizers = append(sanitizers, san)
+	}
+	for _, name := range profile.Guards {
+		san, err := parseSanitizerName(name)
+		if err != nil {
+			return c, err
+		}
+		san.Guard = true
+		sanitizers = append(sanitizers, san)
+	}
+
+	trustedFiles := slices.Clone(c.TrustedFiles)
+	for _, pattern := range profile.TrustedFiles {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return c, fmt.Errorf("invalid trusted file pattern %q: %w", pattern, err)
+		}
+		trustedFiles = append(trustedFiles, re)
+	}
+
+	sources := slices.Clone(c.Sources)
+	var added Label
+	for _, name := range profile.Untrusted {
+		matched, labels, err := resolveTrustEntry(name)
+		if err != nil {
+			return c, err
+		}
+		for _, src := range matched {
+			if !slices.ContainsFunc(sources, func(s Source) bool { return sameSource(s, src) }) {
+				sources = append(sources, src)
+			}
+		}
+		added |= labels
+	}
+	for _, name := range profile.Trusted {
+		matched, _, err := resolveTrustEntry(name)
+		if err != nil {
+			return c, err
+		}
+		sources = slices.DeleteFunc(sources, func(s Source) bool {
+			return slices.ContainsFunc(matched, func(src Source) bool { return sameSource(s, src) })
+		})
+	}
+
+	sinks := c.Sinks
+	if added != 0 {
+		sinks = slices.Clone(c.Sinks)
+		for i := range sinks {
+			// Sinks without labels already consider every source.
+			if sinks[i].Labels != 0 {
+				sinks[i].Labels |= added
+			}
+		}
+	}
+
+	c.Sources = sources
+	c.Sinks = sinks
+	c.TrustedFiles = trustedFiles
+	c.Sanitizers = sanitizers
+	return c, nil
+}
+
+// parseSanitizerName parses a function name, "pkg.Func" or
+// "(*pkg.Type).Method", into a sanitizer.
+func parseSanitizerName(name string) (Sanitizer, error) {
+	var san Sanitizer
+	target := name
+	if strings.HasPrefix(name, "(") {
+		end := strings.Index(name, ").")
+		if end < 0 {
+			return san, fmt.Errorf("invalid sanitizer %q", name)
+		}
+		recv := name[1:end]
+		san.Method = name[end+2:]
+		if strings.HasPrefix(recv, "*") {
+			san.Pointer = true
+			recv = recv[1:]
+		}
+		target = recv
+	}
+	dot := strings.LastIndex(target, ".")
+	if dot <= 0 || dot == len(target)-1 {
+		return san, fmt.Errorf("invalid sanitizer %q", name)
+	}
+	if san.Method == "" {
+		san.Package, san.Method = target[:dot], target[dot+1:]
+	} else {
+		san.Package, san.Receiver = target[:dot], target[dot+1:]
+	}
+	if san.Method == "" {
+		return san, fmt.Errorf("invalid sanitizer %q", name)
+	}
+	return san, nil
+}
+
+// resolveTrustEntry returns the sources of DefaultSources named by a profile
+// entry, and the labels the entry stands for: the named label, or all the
+// labels of a named source.
+func resolveTrustEntry(name string) ([]Source, Label, error) {
+	if label, ok := ParseLabel(name); ok {
+		return SourcesWithLabels(label), label, nil
+	}
+	if !strings.HasPrefix(name, "(") {
+		name = strings.TrimPrefix(name, "*")
+	}
+	for _, src := range DefaultSources {
+		if src.Package+"."+src.Name == name || formatSourceKey(src) == name {
+			return []Source{src}, src.Label, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("unknown taint source %q", name)
+}
+
+func sameSource(a, b Source) bool {
+	return a.Package == b.Package && a.Receiver == b.Receiver && a.Name == b.Name
+}
+
+// labelPrecedence orders labels from the most to the least attacker
+// controlled. A result is attributed to the first label that reaches its sink.
+var labelPrecedence = []Label{LabelHTTPInput, LabelURL, LabelQuery, LabelNetwork, LabelCLI, LabelEnv, LabelFileContent, LabelStored}
+
+// sourceLabel returns the first label of labelPrecedence, relevant to a sink
+// declaring sinkLabels, under which tainted reports a flow. It returns zero
+// when the flow only comes from unlabelled sources.
+func (a *Analyzer) sourceLabel(sinkLabels Label, tainted func() bool) Label {
+	defer func(saved Label) { a.labels = saved }(a.labels)
+	for _, label := range labelPrecedence {
+		if !sinkLabels.matches(label) {
+			continue
+		}
+		a.labels = label
+		if tainted() {
+			return label
+		}
+	}
+	return 0
+}
+
+// sourceConfidence maps the label of the source reaching a sink to the
+// confidence of the issue. Data sent by remote peers is attacker controlled;
+// the environment, the command line and local files are set by whoever runs
+// the program, so flows from them are less certain to be exploitable. Stored
+// data is only exploitable if an attacker managed to persist it earlier.
+func sourceConfidence(label Label) issue.Score {
+	switch label {
+	case LabelEnv, LabelCLI, LabelFileContent:
+		return issue.Medium
+	case LabelStored:
+		return issue.Low
+	default:
+		return issue.High
+	}
+}
@@ -62,4 +62,68 @@ func main() {
 	fmt.Printf("ptr: %p\n", ptr)
 }
 `}, 2, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+func main() {
+	var n int64 = 42
+	v := reflect.NewAt(reflect.TypeOf(n), unsafe.Pointer(&n))
+	fmt.Println(v.Elem().Int())
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+func main() {
+	data := []byte("hello")
+	hdr := (*reflect.SliceHeader)(unsafe.Pointer(&data))
+	fmt.Println(hdr.Len)
+	str := reflect.StringHeader{Data: hdr.Data, Len: hdr.Len}
+	fmt.Println(str.Len)
+}
+`}, 2, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+func main() {
+	data := []byte("hello")
+	ptr := unsafe.Pointer(&data)
+	hdr := (*reflect.SliceHeader)(ptr)
+	fmt.Println(hdr.Len)
+	var n int64 = 42
+	v := reflect.NewAt(reflect.TypeOf(n), ptr)
+	fmt.Println(v.Elem().Int())
+}
+`}, 3, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"fmt"
+	"reflect"
+)
+
+func main() {
+	v := reflect.New(reflect.TypeOf(0))
+	fmt.Println(v.Elem().Int())
+}
+`}, 0, gosec.NewConfig()},
 }
@@ -154,6 +154,112 @@ func main() {
 	_ = b
 }
 `}, 0, gosec.NewConfig()},
+		{[]string{`
+package main
+
+import "os"
+
+func main() {
+	f, err := os.Open("foo.txt")
+	if err != nil {
+		return
+	}
+	defer f.Close()
+}
+`}, 0, gosec.NewConfig()},
+		{[]string{`
+package main
+
+import "os"
+
+func main() {
+	f, err := os.Create("foo.txt")
+	if err != nil {
+		return
+	}
+	defer f.Close()
+}
+`}, 1, gosec.Config{"G104": map[string]interface{}{"defer": "report"}}},
+		{[]string{`
+package main
+
+import (
+	"io"
+	"os"
+)
+
+func copyFile(dst *os.File, src io.ReadCloser) {
+	defer src.Close()
+	if _, err := io.Copy(dst, src); err != nil {
+		return
+	}
+	dst.Close()
+}
+
+func main() {
+	copyFile(os.Stdout, os.Stdin)
+}
+`}, 1, gosec.Config{"G104": map[string]interface{}{
+			"defer":     "report",
+			"io.Closer": map[string]interface{}{"functions": []interface{}{"Close"}, "implements": true, "deferred": true},
+		}}},
+		{[]string{`
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+type console struct{}
+
+func (console) Write(p []byte) (int, error) {
+	return fmt.Print(string(p))
+}
+
+func main() {
+	os.Stdout.Write([]byte("hello"))
+	console{}.Write([]byte("world"))
+}
+`}, 0, gosec.Config{"G104": map[string]interface{}{
+			"io.Writer": map[string]interface{}{"functions": []interface{}{"Write"}, "implements": true},
+		}}},
+		{[]string{`
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	os.Remove("foo.txt")
+	os.RemoveAll("bar")
+	filepath.WalkDir(".", func(string, fs.DirEntry, error) error { return nil })
+	f, _ := os.Open("foo.txt")
+	f.Sync()
+	os.Chmod("foo.txt", 0o600)
+}
+`}, 1, gosec.Config{"G104": map[string]interface{}{
+			"os":            []interface{}{"Remove*"},
+			"path/filepath": []interface{}{"Walk*"},
+			"*os.File":      []interface{}{"*"},
+		}}},
+		{[]string{`
+package main
+
+import "os"
+
+func main() {
+	os.Remove("foo.txt")
+	f, _ := os.Open("foo.txt")
+	f.Sync()
+	os.Chmod("foo.txt", 0o600)
+}
+`}, 0, gosec.Config{"G104": map[string]interface{}{
+			"os.*": []interface{}{"*"},
+		}}},
 	} // it shouldn't return any errors because all method calls are whitelisted by default
 
 	// SampleCodeG104Audit finds errors that aren't being handled in audit mode
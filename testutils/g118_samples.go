@@ -2,6 +2,13 @@ package testutils
 
 import "github.com/securego/gosec/v2"
 
+// contextPropagationConfig turns the given G118 checks on or off.
+func contextPropagationConfig(checks map[string]interface{}) gosec.Config {
+	cfg := gosec.NewConfig()
+	cfg.Set("G118", checks)
+	return cfg
+}
+
 // SampleCodeG118 - Context propagation failures that may leak goroutines/resources
 var SampleCodeG118 = []CodeSample{
 	// Vulnerable: goroutine uses context.Background while request context exists
@@ -352,7 +359,7 @@ func simpleHandler(w http.ResponseWriter, r *http.Request) {
 }
 `}, 0, gosec.NewConfig()},
 
-	// Vulnerable: loop with http.Get blocking call (no ctx.Done guard)
+	// Vulnerable: loop with http.Get blocking call (no ctx.Done guard), and http.Get ignores ctx
 	{[]string{`
 package main
 
@@ -371,7 +378,7 @@ func pollAPI(ctx context.Context) {
 		time.Sleep(time.Second)
 	}
 }
-`}, 1, gosec.NewConfig()},
+`}, 2, gosec.NewConfig()},
 
 	// Vulnerable: loop with database query (no ctx.Done guard)
 	{[]string{`
@@ -409,7 +416,7 @@ func watchFile(ctx context.Context) {
 }
 `}, 1, gosec.NewConfig()},
 
-	// Safe: loop with blocking call AND ctx.Done guard
+	// Safe loop: blocking call AND ctx.Done guard, but http.Get ignores ctx
 	{[]string{`
 package main
 
@@ -434,7 +441,7 @@ func safePoller(ctx context.Context) {
 		}
 	}
 }
-`}, 0, gosec.NewConfig()},
+`}, 1, gosec.NewConfig()},
 
 	// Vulnerable: goroutine with TODO instead of passed context
 	{[]string{`
@@ -587,7 +594,7 @@ func readLoop(ctx context.Context, r io.Reader) {
 }
 `}, 1, gosec.NewConfig()},
 
-	// Safe: loop with http.Client.Do has external exit via error
+	// Safe loop: http.Client.Do has external exit via error, but http.NewRequest ignores ctx
 	{[]string{`
 package main
 
@@ -607,7 +614,7 @@ func fetchWithBreak(ctx context.Context) error {
 	}
 	return nil
 }
-`}, 0, gosec.NewConfig()},
+`}, 1, gosec.NewConfig()},
 
 	// Safe: cancel stored in struct field and called via method (tests isCancelCalledViaStructField)
 	{[]string{`
@@ -1022,7 +1029,7 @@ func postLoop(ctx context.Context) {
 }
 `}, 1, gosec.NewConfig()},
 
-	// Vulnerable: loop with http.PostForm (tests looksLikeBlockingCall PostForm)
+	// Vulnerable: loop with http.PostForm (tests looksLikeBlockingCall PostForm), which ignores ctx
 	{[]string{`
 package main
 
@@ -1037,7 +1044,7 @@ func postFormLoop(ctx context.Context) {
 		http.PostForm("https://example.com", url.Values{})
 	}
 }
-`}, 1, gosec.NewConfig()},
+`}, 2, gosec.NewConfig()},
 
 	// Vulnerable: loop with sql.Begin (tests looksLikeBlockingCall Begin)
 	{[]string{`
@@ -1385,7 +1392,7 @@ func noIssues(ctx context.Context) {
 }
 `}, 0, gosec.NewConfig()},
 
-	// Vulnerable: sql.Query method call (tests looksLikeBlockingCall Query case)
+	// Vulnerable: sql.Query method call (tests looksLikeBlockingCall Query case), which ignores ctx
 	{[]string{`
 package main
 
@@ -1402,7 +1409,7 @@ func queryInLoop(ctx context.Context, db *sql.DB) {
 		}
 	}
 }
-`}, 1, gosec.NewConfig()},
+`}, 2, gosec.NewConfig()},
 
 	// Vulnerable: sql.Exec method call (tests looksLikeBlockingCall Exec case)
 	{[]string{`
@@ -1937,4 +1944,201 @@ func arrayStore(ctx context.Context) [1]context.CancelFunc {
 	return arr
 }
 `}, 0, gosec.NewConfig()},
+	// Vulnerable: http.NewRequest in a handler ignores the request context
+	{[]string{`
+package main
+
+import "net/http"
+
+func proxy(w http.ResponseWriter, r *http.Request) {
+	req, _ := http.NewRequest(http.MethodGet, "https://backend.example.com", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err == nil {
+		resp.Body.Close()
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: http.NewRequestWithContext with the request context
+	{[]string{`
+package main
+
+import "net/http"
+
+func proxy(w http.ResponseWriter, r *http.Request) {
+	req, _ := http.NewRequestWithContext(r.Context(), http.MethodGet, "https://backend.example.com", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err == nil {
+		resp.Body.Close()
+	}
+	w.Header().Set("X-Upstream", r.Header.Get("X-Upstream"))
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: http.Client.Get with a context parameter available
+	{[]string{`
+package main
+
+import (
+	"context"
+	"net/http"
+)
+
+func fetch(ctx context.Context, client *http.Client) error {
+	resp, err := client.Get("https://example.com")
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: http.NewRequest without any context available
+	{[]string{`
+package main
+
+import "net/http"
+
+func fetch(client *http.Client) error {
+	req, err := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: http_request_without_context disabled
+	{[]string{`
+package main
+
+import "net/http"
+
+func proxy(w http.ResponseWriter, r *http.Request) {
+	req, _ := http.NewRequest(http.MethodGet, "https://backend.example.com", nil)
+	_ = req
+}
+`}, 0, contextPropagationConfig(map[string]interface{}{"http_request_without_context": false})},
+
+	// Vulnerable: database/sql calls without their Context variant in a handler
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+var db *sql.DB
+
+func lookup(w http.ResponseWriter, r *http.Request) {
+	var name string
+	_ = db.QueryRow("SELECT name FROM users WHERE id = ?", r.URL.Query().Get("id")).Scan(&name)
+	tx, err := db.BeginTx(r.Context(), nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+	_, _ = tx.Exec("UPDATE users SET seen = 1")
+}
+`}, 2, gosec.NewConfig()},
+
+	// Safe: database/sql Context variants
+	{[]string{`
+package main
+
+import (
+	"context"
+	"database/sql"
+)
+
+func lookup(ctx context.Context, db *sql.DB, id string) (string, error) {
+	var name string
+	err := db.QueryRowContext(ctx, "SELECT name FROM users WHERE id = ?", id).Scan(&name)
+	return name, err
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: sql_without_context disabled
+	{[]string{`
+package main
+
+import (
+	"context"
+	"database/sql"
+)
+
+func ping(ctx context.Context, db *sql.DB) error {
+	return db.Ping()
+}
+`}, 0, contextPropagationConfig(map[string]interface{}{"sql_without_context": false})},
+
+	// Safe: time.Sleep in a handler is only reported when sleep_in_request is enabled
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"time"
+)
+
+func slow(w http.ResponseWriter, r *http.Request) {
+	time.Sleep(2 * time.Second)
+	w.WriteHeader(http.StatusOK)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: time.Sleep and time.After in a handler with sleep_in_request enabled
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"time"
+)
+
+func slow(w http.ResponseWriter, r *http.Request) {
+	time.Sleep(2 * time.Second)
+	<-time.After(time.Second)
+	w.WriteHeader(http.StatusOK)
+}
+`}, 2, contextPropagationConfig(map[string]interface{}{"sleep_in_request": true})},
+
+	// Safe: time.After raced against ctx.Done with sleep_in_request enabled
+	{[]string{`
+package main
+
+import (
+	"context"
+	"time"
+)
+
+func wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Second):
+		return nil
+	}
+}
+`}, 0, contextPropagationConfig(map[string]interface{}{"sleep_in_request": true})},
+
+	// Safe: lost_cancel disabled
+	{[]string{`
+package main
+
+import (
+	"context"
+	"time"
+)
+
+func work(ctx context.Context) {
+	child, _ := context.WithTimeout(ctx, time.Second)
+	_ = child
+}
+`}, 0, contextPropagationConfig(map[string]interface{}{"lost_cancel": false})},
 }
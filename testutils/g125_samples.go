@@ -0,0 +1,298 @@
+package testutils
+
+import "github.com/securego/gosec/v2"
+
+// massAssignmentConfig registers the sample Store type as a persistence sink,
+// since ORM packages are not available to the test build.
+func massAssignmentConfig() gosec.Config {
+	cfg := gosec.NewConfig()
+	cfg.Set("G125", map[string]interface{}{
+		"sinks": map[string]interface{}{
+			"command-line-arguments.Store": []interface{}{"Save", "Updates"},
+		},
+	})
+	return cfg
+}
+
+// SampleCodeG125 - Mass assignment of request bodies into persisted structs
+var SampleCodeG125 = []CodeSample{
+	// Positive: json.NewDecoder(r.Body).Decode into a model with IsAdmin, then saved.
+	{[]string{`
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type User struct {
+	Name    string ` + "`json:\"name\"`" + `
+	IsAdmin bool   ` + "`json:\"is_admin\"`" + `
+}
+
+type Store struct{}
+
+func (s *Store) Save(v interface{}) error { return nil }
+
+var store = &Store{}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	var user User
+	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+		return
+	}
+	_ = store.Save(&user)
+}
+`}, 1, massAssignmentConfig()},
+
+	// Positive: decoder stored in a variable and body wrapped by MaxBytesReader.
+	{[]string{`
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type Account struct {
+	ID      int
+	Balance int64
+}
+
+type Store struct{}
+
+func (s *Store) Updates(v interface{}) error { return nil }
+
+var store = &Store{}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	acct := &Account{}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+	if err := dec.Decode(acct); err != nil {
+		return
+	}
+	_ = store.Updates(acct)
+}
+`}, 1, massAssignmentConfig()},
+
+	// Positive: io.ReadAll + json.Unmarshal with sensitive field in an embedded struct.
+	{[]string{`
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+type Ownership struct {
+	OwnerID int ` + "`json:\"owner_id\"`" + `
+}
+
+type Document struct {
+	Ownership
+	Title string
+}
+
+type Store struct{}
+
+func (s *Store) Save(v interface{}) error { return nil }
+
+var store = &Store{}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return
+	}
+	var doc Document
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return
+	}
+	_ = store.Save(&doc)
+}
+`}, 1, massAssignmentConfig()},
+
+	// Negative: sensitive fields are excluded with json:"-".
+	{[]string{`
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type User struct {
+	Name    string ` + "`json:\"name\"`" + `
+	Role    string ` + "`json:\"-\"`" + `
+	IsAdmin bool   ` + "`json:\"-\"`" + `
+}
+
+type Store struct{}
+
+func (s *Store) Save(v interface{}) error { return nil }
+
+var store = &Store{}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	var user User
+	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+		return
+	}
+	_ = store.Save(&user)
+}
+`}, 0, massAssignmentConfig()},
+
+	// Negative: request decoded into a dedicated input type and copied field by field.
+	{[]string{`
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type User struct {
+	Name string
+	Role string
+}
+
+type UserInput struct {
+	Name string
+}
+
+type Store struct{}
+
+func (s *Store) Save(v interface{}) error { return nil }
+
+var store = &Store{}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	var in UserInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		return
+	}
+	user := User{Name: in.Name, Role: "viewer"}
+	_ = store.Save(&user)
+}
+`}, 0, massAssignmentConfig()},
+
+	// Negative: decoded from a non-request source.
+	{[]string{`
+package main
+
+import (
+	"encoding/json"
+	"os"
+)
+
+type User struct {
+	Name string
+	Role string
+}
+
+type Store struct{}
+
+func (s *Store) Save(v interface{}) error { return nil }
+
+var store = &Store{}
+
+func main() {
+	var user User
+	if err := json.NewDecoder(os.Stdin).Decode(&user); err != nil {
+		return
+	}
+	_ = store.Save(&user)
+}
+`}, 0, massAssignmentConfig()},
+
+	// Negative: Store is not a configured persistence sink by default.
+	{[]string{`
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type User struct {
+	Name string
+	Role string
+}
+
+type Store struct{}
+
+func (s *Store) Save(v interface{}) error { return nil }
+
+var store = &Store{}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	var user User
+	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+		return
+	}
+	_ = store.Save(&user)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: privilege words only inside longer identifiers.
+	{[]string{`
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type Team struct {
+	Name       string ` + "`json:\"name\"`" + `
+	Controller string ` + "`json:\"controller\"`" + `
+	Payroll    int    ` + "`json:\"payroll\"`" + `
+	Badminton  bool   ` + "`json:\"badminton\"`" + `
+	Ownership  string ` + "`json:\"ownership\"`" + `
+}
+
+type Store struct{}
+
+func (s *Store) Save(v interface{}) error { return nil }
+
+var store = &Store{}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	var team Team
+	if err := json.NewDecoder(r.Body).Decode(&team); err != nil {
+		return
+	}
+	_ = store.Save(&team)
+}
+`}, 0, massAssignmentConfig()},
+
+	// Positive: privilege word as the last camelCase segment of the field name.
+	{[]string{`
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type Account struct {
+	Name           string
+	AccountBalance int
+}
+
+type Store struct{}
+
+func (s *Store) Updates(v interface{}) error { return nil }
+
+var store = &Store{}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	var account Account
+	if err := json.NewDecoder(r.Body).Decode(&account); err != nil {
+		return
+	}
+	_ = store.Updates(&account)
+}
+`}, 1, massAssignmentConfig()},
+}
@@ -0,0 +1,186 @@
+package testutils
+
+import "github.com/securego/gosec/v2"
+
+// SampleCodeG126 - Sensitive data written to logs
+var SampleCodeG126 = []CodeSample{
+	// Positive: password passed as slog attribute value.
+	{[]string{`
+package main
+
+import (
+	"log/slog"
+)
+
+func login(user, password string) {
+	slog.Info("login attempt", "user", user, "password", password)
+}
+
+func main() {
+	login("alice", "hunter2")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: secret-looking identifier passed to slog.String with a neutral key.
+	{[]string{`
+package main
+
+import (
+	"log/slog"
+)
+
+func connect(apiToken string) {
+	slog.Debug("connecting", slog.String("value", apiToken))
+}
+
+func main() {
+	connect("abc")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: Authorization header written with the standard logger.
+	{[]string{`
+package main
+
+import (
+	"log"
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("auth header: %s", r.Header.Get("Authorization"))
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: whole request dumped with %+v.
+	{[]string{`
+package main
+
+import (
+	"log"
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("incoming request: %+v", r)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: struct with a secret field dumped through a logger.
+	{[]string{`
+package main
+
+import (
+	"log/slog"
+)
+
+type Config struct {
+	Host     string
+	Password string
+}
+
+func main() {
+	cfg := Config{Host: "db", Password: "s3cr3t"}
+	logger := slog.Default()
+	logger.Info("loaded config", "config", cfg)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: non-secret attributes and transformed secrets.
+	{[]string{`
+package main
+
+import (
+	"log/slog"
+)
+
+func login(user, password string) {
+	slog.Info("login attempt", "user", user, "password_len", len(password))
+	slog.Info("password reset requested", "user", user)
+}
+
+func main() {
+	login("alice", "hunter2")
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: keys that only contain a secret word are not secret attributes.
+	{[]string{`
+package main
+
+import (
+	"log/slog"
+)
+
+func configure(policy, mode, owner string) {
+	slog.Info("configured", "password_policy", policy, "passthrough", mode)
+	slog.Info("configured", "token_type", mode, slog.String("compass", owner))
+}
+
+func main() {
+	configure("strict", "on", "alice")
+}
+`}, 0, gosec.NewConfig()},
+
+	// Positive: secret word as the last segment of a dotted, dashed or camelCase key.
+	{[]string{`
+package main
+
+import (
+	"log/slog"
+)
+
+func connect(user, a, b string) {
+	slog.Info("connecting", "db.password", a)
+	slog.Info("connecting", "userPassword", a)
+	slog.Info("connecting", "x-api-key", b)
+}
+
+func main() {
+	connect("alice", "hunter2", "abc")
+}
+`}, 3, gosec.NewConfig()},
+
+	// Negative: secret field excluded from serialization and non-sensitive header logged.
+	{[]string{`
+package main
+
+import (
+	"log"
+	"log/slog"
+	"net/http"
+)
+
+type Config struct {
+	Host     string
+	Password string ` + "`json:\"-\"`" + `
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("user agent: %s", r.Header.Get("User-Agent"))
+	slog.Info("config", "config", Config{Host: "db"})
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: type controls its own log representation.
+	{[]string{`
+package main
+
+import (
+	"log/slog"
+)
+
+type Credentials struct {
+	User     string
+	Password string
+}
+
+func (c Credentials) LogValue() slog.Value {
+	return slog.StringValue(c.User)
+}
+
+func main() {
+	slog.Info("using credentials", "creds", Credentials{User: "u", Password: "p"})
+}
+`}, 0, gosec.NewConfig()},
+}
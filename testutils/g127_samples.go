@@ -0,0 +1,228 @@
+package testutils
+
+import gosec "github.com/securego/gosec/v2"
+
+// SampleCodeG127 contains samples for detecting goroutines leaked by sends on
+// unbuffered channels that are no longer received from.
+var SampleCodeG127 = []CodeSample{
+	// Positive: select gives up on ctx.Done, the sender blocks forever
+	{
+		Code: []string{`
+package main
+
+import "context"
+
+func compute() int { return 42 }
+
+func fetch(ctx context.Context) (int, error) {
+	ch := make(chan int)
+	go func() {
+		ch <- compute()
+	}()
+	select {
+	case v := <-ch:
+		return v, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Positive: select times out with time.After
+	{
+		Code: []string{`
+package main
+
+import (
+	"errors"
+	"time"
+)
+
+func compute() int { return 42 }
+
+func fetch() (int, error) {
+	ch := make(chan int)
+	go func() {
+		ch <- compute()
+	}()
+	select {
+	case v := <-ch:
+		return v, nil
+	case <-time.After(time.Second):
+		return 0, errors.New("timeout")
+	}
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Positive: early error return before the receive
+	{
+		Code: []string{`
+package main
+
+import "errors"
+
+func compute() int { return 42 }
+
+func fetch(valid bool) (int, error) {
+	ch := make(chan int)
+	go func() {
+		ch <- compute()
+	}()
+	if !valid {
+		return 0, errors.New("invalid")
+	}
+	return <-ch, nil
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Positive: named worker function sending on its parameter
+	{
+		Code: []string{`
+package main
+
+import "context"
+
+func worker(out chan int) {
+	out <- 42
+}
+
+func fetch(ctx context.Context) int {
+	ch := make(chan int)
+	go worker(ch)
+	select {
+	case v := <-ch:
+		return v
+	case <-ctx.Done():
+		return 0
+	}
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: buffered channel lets the sender complete
+	{
+		Code: []string{`
+package main
+
+import "context"
+
+func compute() int { return 42 }
+
+func fetch(ctx context.Context) int {
+	ch := make(chan int, 1)
+	go func() {
+		ch <- compute()
+	}()
+	select {
+	case v := <-ch:
+		return v
+	case <-ctx.Done():
+		return 0
+	}
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: the channel is received from on every path
+	{
+		Code: []string{`
+package main
+
+import "fmt"
+
+func compute() int { return 42 }
+
+func fetch(verbose bool) int {
+	ch := make(chan int)
+	go func() {
+		ch <- compute()
+	}()
+	if verbose {
+		fmt.Println("waiting")
+	}
+	return <-ch
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: the goroutine gives up on ctx.Done as well
+	{
+		Code: []string{`
+package main
+
+import "context"
+
+func compute() int { return 42 }
+
+func fetch(ctx context.Context) int {
+	ch := make(chan int)
+	go func() {
+		select {
+		case ch <- compute():
+		case <-ctx.Done():
+		}
+	}()
+	select {
+	case v := <-ch:
+		return v
+	case <-ctx.Done():
+		return 0
+	}
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: goroutines started in a loop are not counted
+	{
+		Code: []string{`
+package main
+
+func sum(n int) int {
+	ch := make(chan int)
+	for i := 0; i < n; i++ {
+		go func(v int) {
+			ch <- v
+		}(i)
+	}
+	total := 0
+	for i := 0; i < n; i++ {
+		total += <-ch
+	}
+	return total
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: the channel escapes to the caller
+	{
+		Code: []string{`
+package main
+
+func compute() int { return 42 }
+
+func start(fail bool) (<-chan int, bool) {
+	ch := make(chan int)
+	go func() {
+		ch <- compute()
+	}()
+	if fail {
+		return nil, false
+	}
+	return ch, true
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+}
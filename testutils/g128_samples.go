@@ -0,0 +1,433 @@
+package testutils
+
+import gosec "github.com/securego/gosec/v2"
+
+// SampleCodeG128 contains samples for detecting ignored errors of
+// security-critical calls.
+var SampleCodeG128 = []CodeSample{
+	// Positive: the error of ParseCertificate is discarded
+	{
+		Code: []string{`
+package main
+
+import (
+	"crypto/x509"
+	"fmt"
+)
+
+func subject(der []byte) string {
+	cert, _ := x509.ParseCertificate(der)
+	return cert.Subject.String()
+}
+
+func main() {
+	fmt.Println(subject(nil))
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Positive: rand.Read used as a statement leaves the key zeroed on failure
+	{
+		Code: []string{`
+package main
+
+import "crypto/rand"
+
+func newKey() []byte {
+	key := make([]byte, 32)
+	rand.Read(key)
+	return key
+}
+
+func main() {
+	_ = newKey()
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Positive: the key pair is used before the error is checked
+	{
+		Code: []string{`
+package main
+
+import (
+	"crypto/tls"
+	"log"
+)
+
+func config() *tls.Config {
+	pair, err := tls.LoadX509KeyPair("cert.pem", "key.pem")
+	cfg := &tls.Config{Certificates: []tls.Certificate{pair}, MinVersion: tls.VersionTLS12}
+	if err != nil {
+		log.Fatal(err)
+	}
+	return cfg
+}
+
+func main() {
+	_ = config()
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Positive: ignored error of a method listed by default
+	{
+		Code: []string{`
+package main
+
+import (
+	"crypto/x509"
+	"fmt"
+)
+
+func check(cert *x509.Certificate, roots *x509.CertPool) {
+	chains, _ := cert.Verify(x509.VerifyOptions{Roots: roots})
+	fmt.Println(len(chains))
+}
+
+func main() {
+	check(&x509.Certificate{}, nil)
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Positive: the plaintext is used although authentication failed
+	{
+		Code: []string{`
+package main
+
+import (
+	"crypto/cipher"
+	"fmt"
+)
+
+func decrypt(aead cipher.AEAD, nonce, ciphertext []byte) {
+	plaintext, _ := aead.Open(nil, nonce, ciphertext, nil)
+	fmt.Println(string(plaintext))
+}
+
+func main() {
+	decrypt(nil, nil, nil)
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Positive: ignored error of a call added by the configuration
+	{
+		Code: []string{`
+package main
+
+import (
+	"crypto/ecdh"
+	"fmt"
+)
+
+func public(raw []byte) []byte {
+	key, _ := ecdh.X25519().NewPublicKey(raw)
+	return key.Bytes()
+}
+
+func main() {
+	fmt.Println(public(nil))
+}
+`},
+		Errors: 1,
+		Config: gosec.Config{"G128": map[string]any{"crypto/ecdh.Curve": []any{"NewPublicKey"}}},
+	},
+	// Positive: the certificate is used in the branch where the error is not nil
+	{
+		Code: []string{`
+package main
+
+import (
+	"crypto/x509"
+	"log"
+)
+
+func subject(der []byte) string {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		log.Printf("invalid certificate for %s: %v", cert.Subject, err)
+		return ""
+	}
+	return cert.Subject.String()
+}
+
+func main() {
+	_ = subject(nil)
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Positive: the error is only logged before the key pair is used
+	{
+		Code: []string{`
+package main
+
+import (
+	"crypto/tls"
+	"log"
+)
+
+func load() tls.Certificate {
+	pair, err := tls.LoadX509KeyPair("cert.pem", "key.pem")
+	if err != nil {
+		log.Println(err)
+	}
+	return pair
+}
+
+func main() {
+	_ = load()
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: the error is checked before the certificate is used
+	{
+		Code: []string{`
+package main
+
+import (
+	"crypto/x509"
+	"fmt"
+)
+
+func subject(der []byte) (string, error) {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return "", err
+	}
+	return cert.Subject.String(), nil
+}
+
+func main() {
+	fmt.Println(subject(nil))
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: the error is returned to the caller
+	{
+		Code: []string{`
+package main
+
+import "crypto/rand"
+
+func fill(key []byte) error {
+	_, err := rand.Read(key)
+	return err
+}
+
+func main() {
+	_ = fill(make([]byte, 32))
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: the cipher is only used in the success branch
+	{
+		Code: []string{`
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"log"
+)
+
+func newAEAD(key []byte) cipher.AEAD {
+	block, err := aes.NewCipher(key)
+	if err == nil {
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return aead
+	}
+	log.Fatal(err)
+	return nil
+}
+
+func main() {
+	_ = newAEAD(make([]byte, 32))
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: the error branch ends with log.Fatalf
+	{
+		Code: []string{`
+package main
+
+import (
+	"crypto/tls"
+	"log"
+)
+
+func load() tls.Certificate {
+	pair, err := tls.LoadX509KeyPair("cert.pem", "key.pem")
+	if err != nil {
+		log.Fatalf("loading key pair: %v", err)
+	}
+	return pair
+}
+
+func main() {
+	_ = load()
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: the error branch ends with log.Panicf
+	{
+		Code: []string{`
+package main
+
+import (
+	"crypto/tls"
+	"log"
+)
+
+func load() tls.Certificate {
+	pair, err := tls.LoadX509KeyPair("cert.pem", "key.pem")
+	if err != nil {
+		log.Panicf("loading key pair: %v", err)
+	}
+	return pair
+}
+
+func main() {
+	_ = load()
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: the error branch logs the error and calls os.Exit
+	{
+		Code: []string{`
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+)
+
+func load() tls.Certificate {
+	pair, err := tls.LoadX509KeyPair("cert.pem", "key.pem")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	return pair
+}
+
+func main() {
+	_ = load()
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: the error branch panics
+	{
+		Code: []string{`
+package main
+
+import (
+	"crypto/x509"
+	"fmt"
+)
+
+func parse(der []byte) *x509.Certificate {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic(err)
+	}
+	return cert
+}
+
+func main() {
+	fmt.Println(parse(nil).Subject)
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: the error branch stops the test with t.Fatalf
+	{
+		Code: []string{`
+package main
+
+import (
+	"crypto/tls"
+	"testing"
+)
+
+func load(t *testing.T) tls.Certificate {
+	pair, err := tls.LoadX509KeyPair("cert.pem", "key.pem")
+	if err != nil {
+		t.Fatalf("loading key pair: %v", err)
+	}
+	return pair
+}
+
+func main() {
+	_ = load(&testing.T{})
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: ignored errors of calls that are not listed
+	{
+		Code: []string{`
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+func main() {
+	n, _ := strconv.Atoi("42")
+	fmt.Println(n)
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: a default call removed by the configuration
+	{
+		Code: []string{`
+package main
+
+import "crypto/rand"
+
+func newKey() []byte {
+	key := make([]byte, 32)
+	rand.Read(key)
+	return key
+}
+
+func main() {
+	_ = newKey()
+}
+`},
+		Errors: 0,
+		Config: gosec.Config{"G128": map[string]any{"crypto/rand": []any{"!Read"}}},
+	},
+}
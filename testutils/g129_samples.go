@@ -0,0 +1,167 @@
+package testutils
+
+import gosec "github.com/securego/gosec/v2"
+
+var auditConfig = gosec.Config{gosec.Globals: map[gosec.GlobalOption]string{gosec.Audit: "enabled"}}
+
+// SampleCodeG129 contains samples for auditing the use of cgo and raw system
+// calls.
+var SampleCodeG129 = []CodeSample{
+	// Positive: cgo import and a C call; the C string is freed by a defer
+	{
+		Code: []string{`
+package main
+
+/*
+#include <stdlib.h>
+#include <string.h>
+*/
+import "C"
+
+import "unsafe"
+
+func length(s string) int {
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+	return int(C.strlen(cs))
+}
+
+func main() {
+	_ = length("hello")
+}
+`},
+		Errors: 2,
+		Config: auditConfig,
+	},
+	// Positive: the C string leaks on the early return
+	{
+		Code: []string{`
+package main
+
+/*
+#include <stdlib.h>
+#include <string.h>
+*/
+import "C"
+
+import "unsafe"
+
+func length(s string) int {
+	cs := C.CString(s)
+	if len(s) == 0 {
+		return 0
+	}
+	n := int(C.strlen(cs))
+	C.free(unsafe.Pointer(cs))
+	return n
+}
+
+func main() {
+	_ = length("hello")
+}
+`},
+		Errors: 3,
+		Config: auditConfig,
+	},
+	// Positive: Go memory passed to C
+	{
+		Code: []string{`
+package main
+
+/*
+#include <string.h>
+*/
+import "C"
+
+import "unsafe"
+
+func clear(buf []byte) {
+	C.memset(unsafe.Pointer(&buf[0]), 0, C.size_t(len(buf)))
+}
+
+func main() {
+	clear(make([]byte, 16))
+}
+`},
+		Errors: 2,
+		Config: auditConfig,
+	},
+	// Positive: raw system calls
+	{
+		Code: []string{`
+package main
+
+import (
+	"fmt"
+	"syscall"
+)
+
+func main() {
+	pid, _, _ := syscall.RawSyscall(syscall.SYS_GETPID, 0, 0, 0)
+	uid, _, _ := syscall.Syscall(syscall.SYS_GETUID, 0, 0, 0)
+	fmt.Println(pid, uid)
+}
+`},
+		Errors: 2,
+		Config: auditConfig,
+	},
+	// Negative: the C string is returned to the caller, which owns it
+	{
+		Code: []string{`
+package main
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import "unsafe"
+
+func toC(s string) *C.char {
+	return C.CString(s)
+}
+
+func main() {
+	cs := toC("hello")
+	defer C.free(unsafe.Pointer(cs))
+}
+`},
+		Errors: 1,
+		Config: auditConfig,
+	},
+	// Negative: typed system call wrappers
+	{
+		Code: []string{`
+package main
+
+import (
+	"fmt"
+	"syscall"
+)
+
+func main() {
+	fmt.Println(syscall.Getpid(), syscall.Getuid())
+}
+`},
+		Errors: 0,
+		Config: auditConfig,
+	},
+	// Negative: nothing is reported outside of audit mode
+	{
+		Code: []string{`
+package main
+
+import (
+	"fmt"
+	"syscall"
+)
+
+func main() {
+	pid, _, _ := syscall.RawSyscall(syscall.SYS_GETPID, 0, 0, 0)
+	fmt.Println(pid)
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+}
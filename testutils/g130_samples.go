@@ -0,0 +1,198 @@
+package testutils
+
+import gosec "github.com/securego/gosec/v2"
+
+// SampleCodeG130 contains samples for auditing secrets passed to subprocesses
+// through the environment.
+var SampleCodeG130 = []CodeSample{
+	// Positive: the child inherits the token set in the process environment
+	{
+		Code: []string{`
+package main
+
+import (
+	"os"
+	"os/exec"
+)
+
+func main() {
+	os.Setenv("GITHUB_TOKEN", os.Args[1])
+	if err := exec.Command("git", "fetch").Run(); err != nil {
+		panic(err)
+	}
+}
+`},
+		Errors: 1,
+		Config: auditConfig,
+	},
+	// Positive: the environment is extended from os.Environ() in another file
+	{
+		Code: []string{`
+package main
+
+import "os"
+
+func init() {
+	os.Setenv("DB_PASSWORD", os.Getenv("VAULT_DB_PASSWORD"))
+}
+`, `
+package main
+
+import (
+	"os"
+	"os/exec"
+)
+
+func main() {
+	cmd := exec.Command("pg_dump", "app")
+	cmd.Env = append(os.Environ(), "PGHOST=db")
+	if err := cmd.Run(); err != nil {
+		panic(err)
+	}
+}
+`},
+		Errors: 1,
+		Config: auditConfig,
+	},
+	// Positive: a secret field of the configuration is passed to the child
+	{
+		Code: []string{`
+package main
+
+import (
+	"context"
+	"os/exec"
+)
+
+type Config struct {
+	Host       string
+	DBPassword string
+}
+
+func backup(ctx context.Context, cfg Config) error {
+	cmd := exec.CommandContext(ctx, "pg_dump", "-h", cfg.Host, "app")
+	cmd.Env = []string{"PATH=/usr/bin", "PGPASSWORD=" + cfg.DBPassword}
+	return cmd.Run()
+}
+
+func main() {
+	_ = backup(context.Background(), Config{})
+}
+`},
+		Errors: 1,
+		Config: auditConfig,
+	},
+	// Positive: exec.Cmd literal with a secret field in Env
+	{
+		Code: []string{`
+package main
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+type Settings struct {
+	APIKey string
+}
+
+func run(s *Settings) error {
+	cmd := &exec.Cmd{
+		Path: "/usr/local/bin/deploy",
+		Env:  []string{fmt.Sprintf("API_KEY=%s", s.APIKey)},
+	}
+	return cmd.Run()
+}
+
+func main() {
+	_ = run(&Settings{})
+}
+`},
+		Errors: 1,
+		Config: auditConfig,
+	},
+	// Negative: the child gets an explicit environment without the secret
+	{
+		Code: []string{`
+package main
+
+import (
+	"os"
+	"os/exec"
+)
+
+func main() {
+	os.Setenv("GITHUB_TOKEN", os.Args[1])
+	cmd := exec.Command("git", "fetch")
+	cmd.Env = []string{"PATH=/usr/bin", "HOME=/var/empty"}
+	if err := cmd.Run(); err != nil {
+		panic(err)
+	}
+}
+`},
+		Errors: 0,
+		Config: auditConfig,
+	},
+	// Negative: no secret is set in the process environment
+	{
+		Code: []string{`
+package main
+
+import (
+	"os"
+	"os/exec"
+)
+
+func main() {
+	os.Setenv("LANG", "C")
+	cmd := exec.Command("ls", "-l")
+	cmd.Env = append(os.Environ(), "TZ=UTC")
+	_ = cmd.Run()
+}
+`},
+		Errors: 0,
+		Config: auditConfig,
+	},
+	// Negative: the command is returned and configured by the caller
+	{
+		Code: []string{`
+package main
+
+import (
+	"os"
+	"os/exec"
+)
+
+func git(args ...string) *exec.Cmd {
+	return exec.Command("git", args...)
+}
+
+func main() {
+	os.Setenv("GITHUB_TOKEN", os.Args[1])
+	cmd := git("fetch")
+	cmd.Env = []string{"PATH=/usr/bin"}
+	_ = cmd.Run()
+}
+`},
+		Errors: 0,
+		Config: auditConfig,
+	},
+	// Negative: nothing is reported outside of audit mode
+	{
+		Code: []string{`
+package main
+
+import (
+	"os"
+	"os/exec"
+)
+
+func main() {
+	os.Setenv("GITHUB_TOKEN", os.Args[1])
+	_ = exec.Command("git", "fetch").Run()
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+}
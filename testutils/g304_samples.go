@@ -343,5 +343,95 @@ func main() {
 	fn := "filename"
 	open(fn, os.O_RDONLY)
 }
+`}, 0, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("file")
+	if !filepath.IsLocal(name) {
+		http.Error(w, "invalid path", http.StatusBadRequest)
+		return
+	}
+	data, err := os.ReadFile(filepath.Join("/srv/data", name))
+	if err != nil {
+		return
+	}
+	_, _ = w.Write(data)
+}
+
+func main() {
+	http.HandleFunc("/", handler)
+}
+`}, 0, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func read(name string) ([]byte, error) {
+	if filepath.IsLocal(name) {
+		return os.ReadFile(name)
+	}
+	return nil, os.ErrNotExist
+}
+
+func main() {
+	_, _ = read(os.Args[1])
+}
+`}, 0, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func read(name string) ([]byte, error) {
+	if !filepath.IsLocal(name) {
+		return os.ReadFile(name)
+	}
+	return nil, os.ErrNotExist
+}
+
+func main() {
+	_, _ = read(os.Args[1])
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"io"
+	"os"
+)
+
+func read(name string) ([]byte, error) {
+	root, err := os.OpenRoot("/srv/data")
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+	f, err := root.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+func main() {
+	_, _ = read(os.Args[1])
+}
 `}, 0, gosec.NewConfig()},
 }
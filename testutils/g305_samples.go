@@ -173,4 +173,77 @@ func extractFile(f *tar.Header, tr *tar.Reader, destPath string) error {
     return os.Chmod(filePath, f.FileInfo().Mode())
 }
 `}, 1, gosec.NewConfig()},
+	{[]string{`
+package unzip
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func unzip(archive, target string) error {
+	reader, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		if !filepath.IsLocal(file.Name) {
+			continue
+		}
+		path := filepath.Join(target, file.Name)
+
+		fileReader, err := file.Open()
+		if err != nil {
+			return err
+		}
+		targetFile, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+		if err != nil {
+			fileReader.Close()
+			return err
+		}
+		_, err = io.Copy(targetFile, fileReader)
+		targetFile.Close()
+		fileReader.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+`}, 0, gosec.NewConfig()},
+	{[]string{`
+package untar
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func untar(r io.Reader, target string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := header.Name
+		if !filepath.IsLocal(name) {
+			return errors.New("invalid entry name")
+		}
+		if err := os.MkdirAll(filepath.Join(target, name), 0o750); err != nil {
+			return err
+		}
+	}
+}
+`}, 0, gosec.NewConfig()},
 }
@@ -0,0 +1,348 @@
+package testutils
+
+import "github.com/securego/gosec/v2"
+
+// SampleCodeG308 - Archive extraction symlink and hardlink escape
+var SampleCodeG308 = []CodeSample{
+	// Vulnerable: symlink target taken verbatim from the tar header.
+	{[]string{`
+package main
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func extract(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dest, filepath.Clean("/"+hdr.Name))
+		switch hdr.Typeflag {
+		case tar.TypeSymlink:
+			if err := os.Symlink(hdr.Linkname, path); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func main() {
+	_ = extract(os.Stdin, "/tmp/out")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Vulnerable: hardlink target joined with the destination is not validated.
+	{[]string{`
+package main
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func extract(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag == tar.TypeLink {
+			target := filepath.Join(dest, hdr.Linkname)
+			if err := os.Link(target, filepath.Join(dest, "entry")); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func main() {
+	_ = extract(os.Stdin, "/tmp/out")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Vulnerable: strings.HasPrefix selects absolute link targets instead of rejecting them.
+	{[]string{`
+package main
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func extract(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag == tar.TypeSymlink && strings.HasPrefix(hdr.Linkname, "/") {
+			if err := os.Symlink(hdr.Linkname, filepath.Join(dest, "entry")); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func main() {
+	_ = extract(os.Stdin, "/tmp/out")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: link target validated with filepath.IsLocal.
+	{[]string{`
+package main
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func extract(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag == tar.TypeSymlink {
+			if !filepath.IsLocal(hdr.Linkname) {
+				return errors.New("link escapes destination")
+			}
+			if err := os.Symlink(hdr.Linkname, filepath.Join(dest, "entry")); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func main() {
+	_ = extract(os.Stdin, "/tmp/out")
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: resolved target checked with strings.HasPrefix before linking.
+	{[]string{`
+package main
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func extract(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag == tar.TypeLink {
+			target := filepath.Join(dest, hdr.Linkname)
+			if strings.HasPrefix(target, filepath.Clean(dest)+string(os.PathSeparator)) {
+				if err := os.Link(target, filepath.Join(dest, "entry")); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func main() {
+	_ = extract(os.Stdin, "/tmp/out")
+}
+`}, 0, gosec.NewConfig()},
+
+	// Vulnerable: a symlink target resolves against the link directory, not the destination.
+	{[]string{`
+package main
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func extract(r io.Reader, dest string) error {
+	cleanDest := filepath.Clean(dest) + string(os.PathSeparator)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeSymlink {
+			continue
+		}
+		path := filepath.Join(dest, filepath.Clean("/"+hdr.Name))
+		target := filepath.Join(dest, hdr.Linkname)
+		if !strings.HasPrefix(target, cleanDest) {
+			return errors.New("link escapes destination")
+		}
+		if err := os.Symlink(hdr.Linkname, path); err != nil {
+			return err
+		}
+	}
+}
+
+func main() {
+	_ = extract(os.Stdin, "/tmp/out")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Vulnerable: an absolute symlink target is not joined to the link directory.
+	{[]string{`
+package main
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func extract(r io.Reader, dest string) error {
+	cleanDest := filepath.Clean(dest) + string(os.PathSeparator)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeSymlink {
+			continue
+		}
+		path := filepath.Join(dest, filepath.Clean("/"+hdr.Name))
+		target := filepath.Join(filepath.Dir(path), hdr.Linkname)
+		if !strings.HasPrefix(target, cleanDest) {
+			return errors.New("link escapes destination")
+		}
+		if err := os.Symlink(hdr.Linkname, path); err != nil {
+			return err
+		}
+	}
+}
+
+func main() {
+	_ = extract(os.Stdin, "/tmp/out")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Safe: relative symlink target resolved against the link directory is checked.
+	{[]string{`
+package main
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func extract(r io.Reader, dest string) error {
+	cleanDest := filepath.Clean(dest) + string(os.PathSeparator)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeSymlink {
+			continue
+		}
+		path := filepath.Join(dest, filepath.Clean("/"+hdr.Name))
+		if filepath.IsAbs(hdr.Linkname) {
+			return errors.New("absolute link target")
+		}
+		target := filepath.Join(filepath.Dir(path), hdr.Linkname)
+		if !strings.HasPrefix(target, cleanDest) {
+			return errors.New("link escapes destination")
+		}
+		if err := os.Symlink(hdr.Linkname, path); err != nil {
+			return err
+		}
+	}
+}
+
+func main() {
+	_ = extract(os.Stdin, "/tmp/out")
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: links created through os.Root cannot escape the root.
+	{[]string{`
+package main
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+)
+
+func extract(r io.Reader, dest string) error {
+	root, err := os.OpenRoot(dest)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag == tar.TypeSymlink {
+			if err := root.Symlink(hdr.Linkname, hdr.Name); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func main() {
+	_ = extract(os.Stdin, "/tmp/out")
+}
+`}, 0, gosec.NewConfig()},
+
+	// Safe: link target does not come from the archive.
+	{[]string{`
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	_ = os.Symlink("/etc/hosts", "/tmp/hosts")
+}
+`}, 0, gosec.NewConfig()},
+}
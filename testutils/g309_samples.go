@@ -0,0 +1,200 @@
+package testutils
+
+import gosec "github.com/securego/gosec/v2"
+
+// SampleCodeG309 contains samples for detecting file creation races.
+var SampleCodeG309 = []CodeSample{
+	// Positive: the file is created after checking it does not exist
+	{
+		Code: []string{`
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+func createLock(path string) error {
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		return errors.New("already locked")
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func main() {
+	_ = createLock("app.lock")
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Positive: os.Lstat then OpenFile with O_CREATE but no O_EXCL
+	{
+		Code: []string{`
+package main
+
+import "os"
+
+func writeOnce(path string, data []byte) error {
+	if _, err := os.Lstat(path); err == nil {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(data)
+	return err
+}
+
+func main() {
+	_ = writeOnce("out.txt", nil)
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Positive: OpenFile with O_CREATE in /tmp follows symlinks
+	{
+		Code: []string{`
+package main
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	f, err := os.OpenFile(filepath.Join(os.TempDir(), "app.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Positive: files written into a /tmp directory made by os.MkdirAll
+	{
+		Code: []string{`
+package main
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	dir := "/tmp/app-cache"
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "state.json"), []byte("{}"), 0o600); err != nil {
+		panic(err)
+	}
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: O_EXCL makes the creation atomic
+	{
+		Code: []string{`
+package main
+
+import "os"
+
+func createLock(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return os.ErrExist
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func main() {
+	_ = createLock("/tmp/app.lock")
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: the stat checks another path
+	{
+		Code: []string{`
+package main
+
+import "os"
+
+func save(dir, path string, data []byte) error {
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func main() {
+	_ = save("data", "data/out.txt", nil)
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: a private directory made by os.MkdirTemp
+	{
+		Code: []string{`
+package main
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	dir, err := os.MkdirTemp("", "app")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+	f, err := os.OpenFile(filepath.Join(dir, "state.json"), os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+	// Negative: O_NOFOLLOW refuses a planted symlink
+	{
+		Code: []string{`
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+func main() {
+	f, err := os.OpenFile("/tmp/app.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY|syscall.O_NOFOLLOW, 0o600)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+}
+`},
+		Errors: 0,
+		Config: gosec.NewConfig(),
+	},
+}
@@ -0,0 +1,266 @@
+package testutils
+
+import "github.com/securego/gosec/v2"
+
+// SampleCodeG409 - gRPC transport security
+var SampleCodeG409 = []CodeSample{
+	// Positive: grpc.WithInsecure against a remote target.
+	{[]string{`
+package main
+
+import (
+	"google.golang.org/grpc"
+)
+
+func main() {
+	conn, err := grpc.Dial("api.example.com:443", grpc.WithInsecure())
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: insecure.NewCredentials against a remote target.
+	{[]string{`
+package main
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func main() {
+	conn, err := grpc.NewClient("api.example.com:443", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: credentials.NewTLS with InsecureSkipVerify.
+	{[]string{`
+package main
+
+import (
+	"crypto/tls"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func main() {
+	cfg := &tls.Config{InsecureSkipVerify: true}
+	conn, err := grpc.NewClient("api.example.com:443", grpc.WithTransportCredentials(credentials.NewTLS(cfg)))
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: InsecureSkipVerify set on a package-level configuration.
+	{[]string{`
+package main
+
+import (
+	"crypto/tls"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var tlsConfig = &tls.Config{InsecureSkipVerify: true}
+
+func main() {
+	conn, err := grpc.NewClient("api.example.com:443", grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: dial options built separately cannot be tied to a local target.
+	{[]string{`
+package main
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func main() {
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	conn, err := grpc.NewClient("localhost:50051", opts...)
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: server without grpc.Creds.
+	{[]string{`
+package main
+
+import (
+	"net"
+
+	"google.golang.org/grpc"
+)
+
+func main() {
+	lis, err := net.Listen("tcp", "127.0.0.1:50051")
+	if err != nil {
+		panic(err)
+	}
+	srv := grpc.NewServer()
+	_ = srv.Serve(lis)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: server configured with TLS credentials.
+	{[]string{`
+package main
+
+import (
+	"crypto/tls"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func main() {
+	lis, err := net.Listen("tcp", "127.0.0.1:50051")
+	if err != nil {
+		panic(err)
+	}
+	creds := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS13})
+	srv := grpc.NewServer(grpc.Creds(creds))
+	_ = srv.Serve(lis)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Positive: the option slice passed to the server has no credentials.
+	{[]string{`
+package main
+
+import (
+	"net"
+
+	"google.golang.org/grpc"
+)
+
+func main() {
+	lis, err := net.Listen("tcp", ":50051")
+	if err != nil {
+		panic(err)
+	}
+	opts := []grpc.ServerOption{grpc.MaxRecvMsgSize(1 << 20)}
+	srv := grpc.NewServer(opts...)
+	_ = srv.Serve(lis)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: credentials passed through a variable and an option slice.
+	{[]string{`
+package main
+
+import (
+	"crypto/tls"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func main() {
+	lis, err := net.Listen("tcp", ":50051")
+	if err != nil {
+		panic(err)
+	}
+	creds := grpc.Creds(credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS13}))
+	admin := grpc.NewServer(creds)
+	opts := []grpc.ServerOption{grpc.MaxRecvMsgSize(1 << 20)}
+	opts = append(opts, creds)
+	srv := grpc.NewServer(opts...)
+	_, _ = admin, srv.Serve(lis)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: insecure credentials are allowed for loopback and unix targets.
+	{[]string{`
+package main
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const sidecar = "127.0.0.1:15001"
+
+func main() {
+	a, _ := grpc.NewClient("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer a.Close()
+	b, _ := grpc.NewClient("unix:///var/run/agent.sock", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer b.Close()
+	c, _ := grpc.DialContext(context.Background(), sidecar, grpc.WithInsecure())
+	defer c.Close()
+	d, _ := grpc.NewClient("dns:///[::1]:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer d.Close()
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: remote target allowed through configuration.
+	{[]string{`
+package main
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func main() {
+	conn, err := grpc.NewClient("billing.prod.svc.cluster.local:8080", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+}
+`}, 0, func() gosec.Config {
+		cfg := gosec.NewConfig()
+		cfg.Set("G409", map[string]interface{}{
+			"allowed_hosts": []interface{}{`\.svc\.cluster\.local$`},
+		})
+		return cfg
+	}()},
+
+	// Negative: an invalid allowed_hosts pattern is skipped, the valid ones still apply.
+	{[]string{`
+package main
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func main() {
+	conn, err := grpc.NewClient("billing.prod.svc.cluster.local:8080", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+}
+`}, 0, func() gosec.Config {
+		cfg := gosec.NewConfig()
+		cfg.Set("G409", map[string]interface{}{
+			"allowed_hosts": []interface{}{`([a-z`, `\.svc\.cluster\.local$`},
+		})
+		return cfg
+	}()},
+}
@@ -0,0 +1,160 @@
+package testutils
+
+import "github.com/securego/gosec/v2"
+
+// SampleCodeG603 - Integer overflow in arithmetic used for allocation sizes, slice bounds or unsafe offsets
+var SampleCodeG603 = []CodeSample{
+	// Positive: 32-bit multiplication sizing an allocation
+	{[]string{`
+package main
+
+func alloc(count uint32) []uint64 {
+	return make([]uint64, count*8)
+}
+
+func main() {
+	_ = alloc(16)
+}
+`}, 1, gosec.NewConfig()},
+	// Positive: 32-bit addition used as a slice bound
+	{[]string{`
+package main
+
+func window(buf []byte, off, n int32) []byte {
+	return buf[off : off+n]
+}
+
+func main() {
+	_ = window(make([]byte, 8), 0, 4)
+}
+`}, 1, gosec.NewConfig()},
+	// Positive: 32-bit shift used as an index
+	{[]string{`
+package main
+
+func lookup(table []byte, v uint32, bits uint) byte {
+	return table[v<<bits]
+}
+
+func main() {
+	_ = lookup(make([]byte, 8), 1, 2)
+}
+`}, 1, gosec.NewConfig()},
+	// Positive: parsed 64-bit values multiplied into an allocation size
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strconv"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	rows, _ := strconv.Atoi(r.URL.Query().Get("rows"))
+	cols, _ := strconv.Atoi(r.URL.Query().Get("cols"))
+	if rows > 1000 {
+		return
+	}
+	grid := make([]float64, rows*cols)
+	_ = grid
+}
+`}, 1, gosec.NewConfig()},
+	// Positive: length prefix from encoding/binary used in a 32-bit sum
+	{[]string{`
+package main
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+func readRecord(r io.Reader) ([]byte, error) {
+	var n uint32
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, n+4)
+	_, err := io.ReadFull(r, buf)
+	return buf, err
+}
+
+func main() {}
+`}, 1, gosec.NewConfig()},
+	// Positive: unsafe offset computed with overflowing 32-bit arithmetic
+	{[]string{`
+package main
+
+import "unsafe"
+
+func at(base unsafe.Pointer, i, size uint32) unsafe.Pointer {
+	return unsafe.Add(base, i*size)
+}
+
+func main() {}
+`}, 1, gosec.NewConfig()},
+	// Negative: operands bounded by range checks
+	{[]string{`
+package main
+
+func alloc(count uint32) []uint64 {
+	if count > 1024 {
+		return nil
+	}
+	return make([]uint64, count*8)
+}
+
+func main() {
+	_ = alloc(16)
+}
+`}, 0, gosec.NewConfig()},
+	// Negative: 32-bit value widened before the multiplication
+	{[]string{`
+package main
+
+func alloc(count uint32) []uint64 {
+	return make([]uint64, int(count)*8)
+}
+
+func main() {
+	_ = alloc(16)
+}
+`}, 0, gosec.NewConfig()},
+	// Negative: word-sized arithmetic on trusted values
+	{[]string{`
+package main
+
+func window(buf []byte, off, n int) []byte {
+	return buf[off : off+n]
+}
+
+func main() {
+	_ = window(make([]byte, 8), 0, 4)
+}
+`}, 0, gosec.NewConfig()},
+	// Negative: small types bounded by their own range
+	{[]string{`
+package main
+
+func lookup(table []byte, hi, lo uint8) byte {
+	return table[uint16(hi)<<8+uint16(lo)]
+}
+
+func main() {
+	_ = lookup(make([]byte, 1<<16), 1, 2)
+}
+`}, 0, gosec.NewConfig()},
+	// Negative: arithmetic result not used for allocation or indexing
+	{[]string{`
+package main
+
+import "fmt"
+
+func sum(a, b int32) int32 {
+	return a + b
+}
+
+func main() {
+	fmt.Println(sum(1, 2))
+}
+`}, 0, gosec.NewConfig()},
+}
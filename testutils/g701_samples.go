@@ -1254,7 +1254,6 @@ func handler(db *sql.DB, r *http.Request) {
 `}, 0, gosec.NewConfig()},
 
 	// Lookup operation (map access)
-	// NOTE: Map value taint tracking not yet supported - documented limitation
 	{[]string{`
 package main
 
@@ -1270,7 +1269,7 @@ func handler(db *sql.DB, r *http.Request) {
 	query := "SELECT * FROM users WHERE name = '" + userInputs["query"] + "'"
 	db.Query(query)
 }
-`}, 0, gosec.NewConfig()},
+`}, 1, gosec.NewConfig()},
 
 	// Type assertion with tainted data
 	{[]string{`
@@ -2195,5 +2194,275 @@ func handler(db *sql.DB, _ *http.Request) {
 	query := "SELECT * FROM t WHERE host = '" + svc.cfg.Host + "'"
 	db.Query(query)
 }
+`}, 0, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	var b strings.Builder
+	b.WriteString("SELECT * FROM users WHERE name = '")
+	b.WriteString(r.URL.Query().Get("name"))
+	b.WriteString("'")
+	db.Query(b.String())
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "SELECT * FROM users WHERE name = '%s'", r.FormValue("name"))
+	db.Query(buf.String())
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	queries := make(chan string, 1)
+	go func() {
+		queries <- "SELECT * FROM users WHERE name = '" + r.FormValue("name") + "'"
+	}()
+	db.Query(<-queries)
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	filters := make(map[string]string)
+	filters["name"] = r.FormValue("name")
+	db.Query("SELECT * FROM users WHERE name = '" + filters["name"] + "'")
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	table := "users"
+	if strings.HasPrefix(r.FormValue("t"), "admin") {
+		table = "admins"
+	}
+	db.Query(fmt.Sprintf("SELECT * FROM %s LIMIT %d", table, len(r.FormValue("q"))))
+}
+`}, 0, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"os"
+)
+
+func main() {
+	db, _ := sql.Open("sqlite3", ":memory:")
+	query, _ := os.ReadFile("query.sql")
+	_, _ = db.Query(string(query))
+}
+`}, 0, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"os"
+)
+
+func main() {
+	db, _ := sql.Open("sqlite3", ":memory:")
+	query, _ := os.ReadFile("query.sql")
+	_, _ = db.Query(string(query))
+}
+`}, 1, gosec.Config{"G701": map[string]interface{}{"untrusted": []interface{}{"file-content"}}}},
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"os"
+)
+
+func main() {
+	db, _ := sql.Open("sqlite3", ":memory:")
+	query, _ := os.ReadFile("/etc/app/query.sql")
+	_, _ = db.Query(string(query))
+}
+`}, 0, gosec.Config{"G701": map[string]interface{}{"untrusted": []interface{}{"file-content"}, "trusted_files": []interface{}{"^/etc/app/"}}}},
+	// Second-order SQL injection: values read back from the database are
+	// only sources when the rule trusts no stored data.
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+)
+
+func rename(db *sql.DB, id int) error {
+	var name string
+	if err := db.QueryRow("SELECT name FROM users WHERE id = $1", id).Scan(&name); err != nil {
+		return err
+	}
+	_, err := db.Exec("UPDATE audit SET owner = '" + name + "'")
+	return err
+}
+
+func main() {
+	db, _ := sql.Open("sqlite3", ":memory:")
+	_ = rename(db, 1)
+}
+`}, 0, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+)
+
+func rename(db *sql.DB, id int) error {
+	var name string
+	if err := db.QueryRow("SELECT name FROM users WHERE id = $1", id).Scan(&name); err != nil {
+		return err
+	}
+	_, err := db.Exec("UPDATE audit SET owner = '" + name + "'")
+	return err
+}
+
+func main() {
+	db, _ := sql.Open("sqlite3", ":memory:")
+	_ = rename(db, 1)
+}
+`}, 1, gosec.Config{"G701": map[string]interface{}{"untrusted": []interface{}{"stored"}}}},
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+)
+
+func rename(db *sql.DB, id int) error {
+	var name string
+	if err := db.QueryRow("SELECT name FROM users WHERE id = $1", id).Scan(&name); err != nil {
+		return err
+	}
+	_, err := db.Exec("UPDATE audit SET owner = $1", name)
+	return err
+}
+
+func main() {
+	db, _ := sql.Open("sqlite3", ":memory:")
+	_ = rename(db, 1)
+}
+`}, 0, gosec.Config{"G701": map[string]interface{}{"untrusted": []interface{}{"stored"}}}},
+
+	// Sinks called from a goroutine, through a method value and through a
+	// method value stored in a struct field
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	go db.Exec("DELETE FROM sessions WHERE user = '" + r.FormValue("user") + "'")
+}
+
+func main() {}
+`}, 1, gosec.NewConfig()},
+
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+func handler(db *sql.DB, r *http.Request) {
+	query := db.Query
+	rows, _ := query("SELECT * FROM users WHERE name = '" + r.FormValue("name") + "'")
+	_ = rows
+}
+
+func main() {}
+`}, 1, gosec.NewConfig()},
+
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type store struct {
+	query func(string, ...any) (*sql.Rows, error)
+}
+
+func newStore(db *sql.DB) *store {
+	return &store{query: db.Query}
+}
+
+func (s *store) handler(w http.ResponseWriter, r *http.Request) {
+	rows, _ := s.query("SELECT * FROM users WHERE name = '" + r.FormValue("name") + "'")
+	_ = rows
+}
+
+func main() {}
+`}, 1, gosec.NewConfig()},
+
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+type store struct {
+	query func(string, ...any) (*sql.Rows, error)
+}
+
+func newStore(db *sql.DB) *store {
+	return &store{query: db.Query}
+}
+
+func (s *store) handler(w http.ResponseWriter, r *http.Request) {
+	rows, _ := s.query("SELECT * FROM users WHERE name = $1", r.FormValue("name"))
+	_ = rows
+}
+
+func main() {}
 `}, 0, gosec.NewConfig()},
 }
@@ -43,4 +43,180 @@ func safeCommand() {
 	exec.Command("ls", "-la").Run()
 }
 `}, 0, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os/exec"
+)
+
+var allowedTools = map[string]bool{"uptime": true, "df": true}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	tool := r.URL.Query().Get("tool")
+	if !allowedTools[tool] {
+		http.Error(w, "unknown tool", http.StatusBadRequest)
+		return
+	}
+	_ = exec.Command(tool).Run()
+}
+`}, 0, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os/exec"
+)
+
+var allowedTools = map[string]struct{}{"uptime": {}, "df": {}}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	tool := r.URL.Query().Get("tool")
+	if _, ok := allowedTools[tool]; !ok {
+		_ = exec.Command(tool).Run()
+	}
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"os"
+	"os/exec"
+)
+
+func main() {
+	_ = exec.Command(os.Args[1], os.Args[2:]...).Run()
+}
+`}, 0, gosec.Config{"G702": map[string]interface{}{"trusted": []interface{}{"os.Args"}}}},
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"os/exec"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	_ = exec.Command(os.Getenv("TOOL")).Run()
+	_ = exec.Command(r.URL.Query().Get("tool")).Run()
+}
+`}, 1, gosec.Config{"G702": map[string]interface{}{"trusted": []interface{}{"cli", "env"}}}},
+
+	// Sinks deferred and called through a function stored in a struct field
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os/exec"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	defer exec.Command("sh", "-c", r.FormValue("cleanup"))
+}
+
+func main() {}
+`}, 1, gosec.NewConfig()},
+
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os/exec"
+)
+
+type runner struct {
+	command func(string, ...string) *exec.Cmd
+}
+
+var defaultRunner = runner{command: exec.Command}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	_ = defaultRunner.command("sh", "-c", r.FormValue("cmd")).Run()
+}
+
+func main() {}
+`}, 1, gosec.NewConfig()},
+
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os/exec"
+)
+
+type runner struct {
+	command func(string, ...string) *exec.Cmd
+}
+
+func fake(name string, args ...string) *exec.Cmd { return nil }
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	rn := runner{command: fake}
+	_ = rn.command("sh", "-c", r.FormValue("cmd"))
+}
+
+func main() {}
+`}, 0, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"errors"
+	"net/http"
+	"os/exec"
+	"regexp"
+)
+
+var toolName = regexp.MustCompile("^[a-z]+$")
+
+func validateTool(name string) error {
+	if !toolName.MatchString(name) {
+		return errors.New("invalid tool")
+	}
+	return nil
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	tool := r.URL.Query().Get("tool")
+	if err := validateTool(tool); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_ = exec.Command(tool).Run()
+}
+`}, 0, func() gosec.Config {
+		cfg := gosec.NewConfig()
+		cfg.Set("G702", map[string]interface{}{
+			// Samples are loaded as files, in the command-line-arguments package
+			"validators": []interface{}{"command-line-arguments.validateTool"},
+		})
+		return cfg
+	}()},
+
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os/exec"
+)
+
+var seenTools = map[string]bool{}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	tool := r.URL.Query().Get("tool")
+	if !seenTools[tool] {
+		seenTools[tool] = true
+		return
+	}
+	_ = exec.Command(tool).Run()
+}
+`}, 1, gosec.NewConfig()},
 }
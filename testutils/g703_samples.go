@@ -185,4 +185,166 @@ func handler(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, "static/index.html")
 }
 `}, 0, gosec.NewConfig()},
+	// True negative: early return when filepath.IsLocal rejects the name
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("file")
+	if !filepath.IsLocal(name) {
+		http.Error(w, "invalid path", http.StatusBadRequest)
+		return
+	}
+	data, err := os.ReadFile(filepath.Join("/srv/data", name))
+	if err != nil {
+		return
+	}
+	_, _ = w.Write(data)
+}
+`}, 0, gosec.NewConfig()},
+	// True negative: sink only used inside the branch where filepath.IsLocal is true
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("file")
+	if filepath.IsLocal(name) {
+		f, err := os.Open(filepath.Join("/srv/data", name))
+		if err == nil {
+			_ = f.Close()
+		}
+	}
+}
+`}, 0, gosec.NewConfig()},
+	// True positive: sink in the branch where filepath.IsLocal returned false
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("file")
+	if filepath.IsLocal(name) {
+		return
+	}
+	_ = os.Remove(name)
+}
+`}, 1, gosec.NewConfig()},
+	// True positive: guard combined with || does not protect the sink
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("file")
+	if filepath.IsLocal(name) || r.URL.Query().Get("force") == "1" {
+		_ = os.Remove(name)
+	}
+}
+`}, 1, gosec.NewConfig()},
+	// True negative: guard on a variable captured by a closure
+	{[]string{`
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("file")
+	defer func() { log.Printf("served %s", name) }()
+	if !filepath.IsLocal(name) {
+		return
+	}
+	_ = os.Remove(filepath.Join("/srv/data", name))
+}
+`}, 0, gosec.NewConfig()},
+	// True positive: the captured variable is reassigned between the guard and the sink
+	{[]string{`
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("file")
+	defer func() { log.Printf("served %s", name) }()
+	if !filepath.IsLocal(name) {
+		return
+	}
+	name = r.URL.Query().Get("backup")
+	_ = os.Remove(filepath.Join("/srv/data", name))
+}
+`}, 1, gosec.NewConfig()},
+	// True negative: os.Root scopes the user-supplied name beneath a fixed root
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	root, err := os.OpenRoot("/srv/data")
+	if err != nil {
+		return
+	}
+	defer root.Close()
+	f, err := root.Open(r.URL.Query().Get("file"))
+	if err != nil {
+		return
+	}
+	_ = f.Close()
+	g, err := os.OpenInRoot("/srv/data", r.FormValue("other"))
+	if err != nil {
+		return
+	}
+	_ = g.Close()
+}
+`}, 0, gosec.NewConfig()},
+
+	// Sink called from a goroutine
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	go os.WriteFile(r.FormValue("name"), []byte("uploaded"), 0o600)
+}
+
+func main() {}
+`}, 1, gosec.NewConfig()},
 }
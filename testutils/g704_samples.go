@@ -176,4 +176,66 @@ func doImport(httpDoer HTTPDoer) error {
 	return nil
 }
 `}, 1, gosec.NewConfig()},
+
+	// Sinks called from a goroutine and through a function variable
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	go http.Get(r.URL.Query().Get("callback"))
+}
+
+func main() {}
+`}, 1, gosec.NewConfig()},
+
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	fetch := http.Get
+	if r.Method == http.MethodPost {
+		fetch = http.DefaultClient.Get
+	}
+	resp, err := fetch(r.URL.Query().Get("callback"))
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+}
+
+func main() {}
+`}, 1, gosec.NewConfig()},
+	// Exported helpers taking a parsed URL or query values are fed by the
+	// program itself; they must NOT trigger G704.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"net/url"
+)
+
+func Fetch(u *url.URL) {
+	resp, err := http.Get(u.String())
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+}
+
+func Ping(q url.Values) {
+	resp, err := http.Get("https://status.example.com/ping?" + q.Encode())
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+}
+
+func main() {}
+`}, 0, gosec.NewConfig()},
 }
@@ -193,5 +193,146 @@ func main() {
 	http.HandleFunc("/run", handler)
 	_ = http.ListenAndServe(":8080", nil)
 }
+`}, 1, gosec.NewConfig()},
+
+	// Stored XSS: rows read back from the database reach the response when
+	// the rule is configured to distrust stored data.
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+)
+
+type comment struct {
+	Author string
+	Body   string
+}
+
+func comments(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rows, err := db.Query("SELECT author, body FROM comments")
+		if err != nil {
+			return
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var c comment
+			if err := rows.Scan(&c.Author, &c.Body); err != nil {
+				return
+			}
+			fmt.Fprintf(w, "<p>%s</p>", c.Body)
+		}
+	}
+}
+
+func main() {
+	db, _ := sql.Open("postgres", "")
+	http.Handle("/comments", comments(db))
+	_ = http.ListenAndServe(":8080", nil)
+}
+`}, 0, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+)
+
+type comment struct {
+	Author string
+	Body   string
+}
+
+func comments(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rows, err := db.Query("SELECT author, body FROM comments")
+		if err != nil {
+			return
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var c comment
+			if err := rows.Scan(&c.Author, &c.Body); err != nil {
+				return
+			}
+			fmt.Fprintf(w, "<p>%s</p>", c.Body)
+		}
+	}
+}
+
+func main() {
+	db, _ := sql.Open("postgres", "")
+	http.Handle("/comments", comments(db))
+	_ = http.ListenAndServe(":8080", nil)
+}
+`}, 1, gosec.Config{"G705": map[string]interface{}{"untrusted": []interface{}{"stored"}}}},
+	{[]string{`
+package main
+
+import (
+	"io"
+	"net/http"
+	"os"
+)
+
+func page(w http.ResponseWriter, r *http.Request) {
+	f, err := os.Open("/var/lib/app/banner.html")
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	banner, _ := io.ReadAll(f)
+	_, _ = w.Write(banner)
+}
+
+func main() {
+	http.HandleFunc("/", page)
+	_ = http.ListenAndServe(":8080", nil)
+}
+`}, 1, gosec.Config{"G705": map[string]interface{}{"untrusted": []interface{}{"stored"}}}},
+	{[]string{`
+package main
+
+import (
+	"io"
+	"net/http"
+	"os"
+)
+
+func page(w http.ResponseWriter, r *http.Request) {
+	f, err := os.Open("/var/lib/app/banner.html")
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	banner, _ := io.ReadAll(f)
+	_, _ = w.Write(banner)
+}
+
+func main() {
+	http.HandleFunc("/", page)
+	_ = http.ListenAndServe(":8080", nil)
+}
+`}, 0, gosec.Config{"G705": map[string]interface{}{"untrusted": []interface{}{"stored"}, "trusted_files": []interface{}{"^/var/lib/app/"}}}},
+
+	// Sink deferred until the handler returns
+	{[]string{`
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	defer fmt.Fprintf(w, "<p>Goodbye %s</p>", r.FormValue("name"))
+}
+
+func main() {}
 `}, 1, gosec.NewConfig()},
 }
@@ -135,4 +135,27 @@ func handler(r *http.Request) {
 	log.Printf("Processing ID: %d", num)
 }
 `}, 0, gosec.NewConfig()},
+
+	// Logger stored in a struct field
+	{[]string{`
+package main
+
+import (
+	"log"
+	"net/http"
+)
+
+type server struct {
+	logf func(string, ...any)
+}
+
+func (s *server) handler(w http.ResponseWriter, r *http.Request) {
+	s.logf("login attempt for %s", r.FormValue("user"))
+}
+
+func main() {
+	s := &server{logf: log.Printf}
+	http.HandleFunc("/login", s.handler)
+}
+`}, 1, gosec.NewConfig()},
 }
@@ -72,5 +72,62 @@ func handler(r *http.Request) {
 
 	_ = smtp.SendMail("127.0.0.1:25", nil, "sender@example.com", recipients, []byte("Subject: Hi\r\n\r\nbody"))
 }
+`}, 0, gosec.NewConfig()},
+
+	// Sinks called from a goroutine, deferred and called through a method value
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"net/smtp"
+)
+
+func handler(r *http.Request) {
+	go smtp.SendMail("127.0.0.1:25", nil, r.FormValue("from"), []string{"ops@example.com"}, []byte("Subject: Hi\r\n\r\nbody"))
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"net/smtp"
+)
+
+func handler(r *http.Request, c *smtp.Client) {
+	defer c.Rcpt(r.FormValue("to"))
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"net/smtp"
+)
+
+func handler(r *http.Request, c *smtp.Client) {
+	mail := c.Mail
+	_ = mail(r.FormValue("from"))
+}
+`}, 1, gosec.NewConfig()},
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"net/mail"
+	"net/smtp"
+)
+
+func handler(r *http.Request, c *smtp.Client) {
+	parsed, err := mail.ParseAddress(r.FormValue("from"))
+	if err != nil {
+		return
+	}
+	send := c.Mail
+	_ = send(parsed.Address)
+}
 `}, 0, gosec.NewConfig()},
 }
@@ -103,4 +103,58 @@ func handler(w http.ResponseWriter, r *http.Request) {
 	tmpl.Execute(w, safe)
 }
 `}, 0, gosec.NewConfig()},
+
+	// Positive: Execute called from a goroutine
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"text/template"
+)
+
+var tmpl = template.Must(template.New("page").Parse(` + "`<h1>Hello {{.}}</h1>`" + `))
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	done := make(chan error)
+	go func() { done <- tmpl.Execute(w, r.FormValue("name")) }()
+	<-done
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: deferred Execute
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"text/template"
+)
+
+var tmpl = template.Must(template.New("page").Parse(` + "`<h1>Hello {{.}}</h1>`" + `))
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	defer tmpl.Execute(w, r.FormValue("name"))
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: Parse called through a method value
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"os"
+	"text/template"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	parse := template.New("page").Parse
+	t, err := parse(r.FormValue("tmpl"))
+	if err != nil {
+		return
+	}
+	t.Execute(os.Stdout, nil)
+}
+`}, 1, gosec.NewConfig()},
 }
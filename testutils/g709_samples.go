@@ -133,4 +133,73 @@ func handler(w http.ResponseWriter, r *http.Request) {
 		Errors: 0,
 		Config: gosec.NewConfig(),
 	},
+	// Positive: gob.NewDecoder called from a goroutine
+	{
+		Code: []string{`
+package main
+
+import (
+	"encoding/gob"
+	"net/http"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	go gob.NewDecoder(strings.NewReader(r.FormValue("data")))
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Positive: deferred xml.Unmarshal of user input
+	{
+		Code: []string{`
+package main
+
+import (
+	"encoding/xml"
+	"net/http"
+)
+
+type Order struct {
+	ID string
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	var order Order
+	defer xml.Unmarshal([]byte(r.FormValue("order")), &order)
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
+	// Positive: xml.Unmarshal reached through the method value of a codec
+	{
+		Code: []string{`
+package main
+
+import (
+	"encoding/xml"
+	"net/http"
+)
+
+type Order struct {
+	ID string
+}
+
+type codec struct{}
+
+func (codec) decode(data []byte, v any) error {
+	return xml.Unmarshal(data, v)
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	decode := codec{}.decode
+	var order Order
+	_ = decode([]byte(r.FormValue("order")), &order)
+}
+`},
+		Errors: 1,
+		Config: gosec.NewConfig(),
+	},
 }
@@ -65,4 +65,20 @@ func handler(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, fmt.Sprintf("/users/%d", id), http.StatusFound)
 }
 `}, 0, gosec.NewConfig()},
+
+	// Redirect through a function value captured by a closure
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func main() {
+	redirect := http.Redirect
+	http.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		redirect(w, r, r.FormValue("next"), http.StatusFound)
+	})
+}
+`}, 1, gosec.NewConfig()},
 }
@@ -0,0 +1,213 @@
+package testutils
+
+import "github.com/securego/gosec/v2"
+
+// SampleCodeG711 - HTTP response header injection via taint analysis
+var SampleCodeG711 = []CodeSample{
+	// Positive: query parameter flows directly into Header().Set value.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	lang := r.URL.Query().Get("lang")
+	w.Header().Set("Content-Language", lang)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: form value used as header name in Header().Add.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	name := r.FormValue("h")
+	w.Header().Add(name, "1")
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: tainted cookie value passed to http.SetCookie.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	session := r.URL.Query().Get("session")
+	http.SetCookie(w, &http.Cookie{Name: "session", Value: session, Secure: true, HttpOnly: true})
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: direct map store into the response header.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	v := r.Header.Get("X-Forwarded-Host")
+	w.Header()["X-Origin-Host"] = []string{v}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: strings.ReplaceAll of unrelated characters does not strip CR/LF.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	lang := strings.ReplaceAll(r.URL.Query().Get("lang"), "_", "-")
+	w.Header().Set("Content-Language", lang)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: CR and LF stripped with strings.ReplaceAll.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	lang := r.URL.Query().Get("lang")
+	lang = strings.ReplaceAll(lang, "\r", "")
+	lang = strings.ReplaceAll(lang, "\n", "")
+	w.Header().Set("Content-Language", lang)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Positive: only CR is stripped, LF still splits the header.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	lang := strings.ReplaceAll(r.URL.Query().Get("lang"), "\r", "")
+	w.Header().Set("Content-Language", lang)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: only LF is stripped before a direct map store.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	v := strings.ReplaceAll(r.FormValue("v"), "\n", "")
+	w.Header()["X-Val"] = []string{v}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: the CRLF sequence is stripped but a lone CR or LF is kept.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	v := strings.ReplaceAll(r.FormValue("v"), "\r\n", "")
+	w.Header()["X-Val"] = []string{v}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: CR and LF stripped before a direct map store.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	v := r.FormValue("v")
+	w.Header()["X-Val"] = []string{strings.ReplaceAll(strings.ReplaceAll(v, "\r", ""), "\n", "")}
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: CR and LF replaced with strings.Replace and a negative count.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	v := strings.Replace(r.FormValue("v"), "\n", " ", -1)
+	v = strings.Replace(v, "\r", " ", -1)
+	w.Header().Set("X-Val", "id="+v)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: value trimmed with textproto.TrimString.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"net/textproto"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	v := textproto.TrimString(r.FormValue("v"))
+	w.Header().Set("X-Value", v)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: tainted data only reaches cookie fields that are not emitted verbatim.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Query().Get("path")
+	http.SetCookie(w, &http.Cookie{Name: "session", Value: "static", Path: path, Secure: true, HttpOnly: true})
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: constant header values.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header()["Cache-Control"] = []string{"no-store"}
+}
+`}, 0, gosec.NewConfig()},
+}
@@ -0,0 +1,198 @@
+package testutils
+
+import "github.com/securego/gosec/v2"
+
+// SampleCodeG712 - Memory allocation sized by untrusted input via taint analysis
+var SampleCodeG712 = []CodeSample{
+	// Positive: header value converted with strconv.Atoi sizes a slice.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strconv"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	n, err := strconv.Atoi(r.Header.Get("X-Size"))
+	if err != nil {
+		return
+	}
+	buf := make([]byte, n)
+	_, _ = r.Body.Read(buf)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: length prefix read with binary.Read sizes the buffer of io.ReadFull.
+	{[]string{`
+package main
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+)
+
+func readFrame(conn net.Conn) ([]byte, error) {
+	var size uint32
+	if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: query parameter used as the capacity of a slice.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strconv"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	n, _ := strconv.Atoi(r.URL.Query().Get("n"))
+	items := make([]string, 0, n)
+	_ = items
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: strings.Repeat count from a form value.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	count, _ := strconv.Atoi(r.FormValue("count"))
+	_, _ = w.Write([]byte(strings.Repeat("-", count)))
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: bytes.Repeat and io.CopyN sized by query parameters.
+	{[]string{`
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	n, _ := strconv.ParseInt(r.URL.Query().Get("n"), 10, 64)
+	var buf bytes.Buffer
+	_, _ = io.CopyN(&buf, r.Body, n)
+	_, _ = w.Write(bytes.Repeat([]byte("a"), int(n)))
+}
+`}, 2, gosec.NewConfig()},
+
+	// Positive: size passed to a helper allocating the buffer.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strconv"
+)
+
+func alloc(n int) []byte {
+	return make([]byte, n)
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	n, _ := strconv.Atoi(r.URL.Query().Get("n"))
+	_, _ = w.Write(alloc(n))
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: upper-bound check dominates the allocation.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strconv"
+)
+
+const maxSize = 1 << 20
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	n, err := strconv.Atoi(r.Header.Get("X-Size"))
+	if err != nil || n < 0 || n > maxSize {
+		http.Error(w, "invalid size", http.StatusBadRequest)
+		return
+	}
+	buf := make([]byte, n)
+	_, _ = r.Body.Read(buf)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: length prefix checked against a maximum frame size.
+	{[]string{`
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+)
+
+func readFrame(conn net.Conn) ([]byte, error) {
+	var size uint32
+	if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	if size > 64*1024 {
+		return nil, errors.New("frame too large")
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: constant and locally computed sizes.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	buf := make([]byte, 4096)
+	_, _ = r.Body.Read(buf)
+	name := r.URL.Query().Get("name")
+	_, _ = w.Write([]byte(strings.Repeat("=", 10) + name))
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: the slice is sized by the length of existing input.
+	{[]string{`
+package main
+
+import "net/http"
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()["v"]
+	out := make([]string, 0, 16)
+	out = append(out, values...)
+	_ = out
+}
+`}, 0, gosec.NewConfig()},
+}
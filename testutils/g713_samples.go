@@ -0,0 +1,152 @@
+package testutils
+
+import "github.com/securego/gosec/v2"
+
+// SampleCodeG713 - Unsafe reflection and plugin loading via taint analysis
+var SampleCodeG713 = []CodeSample{
+	// Positive: query parameter selects the method called through reflection.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"reflect"
+)
+
+type Service struct{}
+
+func (Service) Status() string { return "ok" }
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	action := r.URL.Query().Get("action")
+	m := reflect.ValueOf(Service{}).MethodByName(action)
+	m.Call(nil)
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: form value selects the struct field that is written.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"reflect"
+)
+
+type Account struct {
+	Name  string
+	Admin bool
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	var acct Account
+	field := reflect.ValueOf(&acct).Elem().FieldByName(r.FormValue("field"))
+	if field.Kind() == reflect.String {
+		field.SetString(r.FormValue("value"))
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: plugin path built from a command-line argument.
+	{[]string{`
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"plugin"
+)
+
+func main() {
+	p, err := plugin.Open(filepath.Join("plugins", os.Args[1]+".so"))
+	if err != nil {
+		panic(err)
+	}
+	_ = p
+}
+`}, 1, gosec.NewConfig()},
+
+	// Positive: symbol name looked up from a request header.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"plugin"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	p, err := plugin.Open("handlers.so")
+	if err != nil {
+		return
+	}
+	sym, err := p.Lookup(r.Header.Get("X-Handler"))
+	if err != nil {
+		return
+	}
+	if fn, ok := sym.(func(http.ResponseWriter, *http.Request)); ok {
+		fn(w, r)
+	}
+}
+`}, 1, gosec.NewConfig()},
+
+	// Negative: the method name is a constant.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"reflect"
+)
+
+type Service struct{}
+
+func (Service) Status() string { return "ok" }
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	m := reflect.ValueOf(Service{}).MethodByName("Status")
+	m.Call(nil)
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: the untrusted input selects an entry of a fixed table.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"plugin"
+)
+
+var plugins = map[string]string{
+	"auth":  "/opt/app/plugins/auth.so",
+	"audit": "/opt/app/plugins/audit.so",
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	path, ok := plugins[r.URL.Query().Get("plugin")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if _, err := plugin.Open(path); err != nil {
+		http.Error(w, "unavailable", http.StatusInternalServerError)
+	}
+}
+`}, 0, gosec.NewConfig()},
+
+	// Negative: the tainted value is the receiver, not the name.
+	{[]string{`
+package main
+
+import (
+	"net/http"
+	"reflect"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	v := reflect.ValueOf(r.URL.Query())
+	_ = v.MethodByName("Get")
+}
+`}, 0, gosec.NewConfig()},
+}